@@ -0,0 +1,162 @@
+package goseal
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// FieldAdvice suggests unexporting an exported field of a sealed struct that
+// is not used outside its package.
+type FieldAdvice struct {
+	Position token.Position // Position of the field declaration
+	Package  string         // Package declaring the struct
+	Struct   string         // Name of the sealed struct
+	Field    string         // Name of the field
+}
+
+// Message describes the advice.
+func (a FieldAdvice) Message() string {
+	return fmt.Sprintf(
+		"exported field %s of sealed struct %s is never used outside its package; unexport it and add an accessor if needed",
+		a.Field,
+		a.Struct,
+	)
+}
+
+// AdviseEncapsulation returns the exported fields of the sealed structs
+// declared in pkgs that are neither written nor read by any other package in
+// pkgs. Only the loaded packages are considered, so pkgs should include every
+// consumer of the structs (e.g. ./... of the module, with tests).
+func AdviseEncapsulation(config *Config, pkgs []*packages.Package) []FieldAdvice {
+	c := &goseal{config: config}
+
+	// Test variants of a package declare distinct objects for the same
+	// fields, so fields are identified by position.
+	candidates := make(map[token.Position]FieldAdvice)
+	for _, pkg := range pkgs {
+		// Skip test variants; their non-test files are in the plain package
+		if pkg.ID != pkg.PkgPath || pkg.Types == nil || !c.isTargetPackage(pkg.PkgPath) {
+			continue
+		}
+
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || obj.IsAlias() || c.isExcludedStruct(name) {
+				continue
+			}
+			st, ok := obj.Type().Underlying().(*types.Struct)
+			if !ok {
+				continue
+			}
+
+			for i := range st.NumFields() {
+				field := st.Field(i)
+				if !field.Exported() || field.Embedded() {
+					continue
+				}
+				tag := reflect.StructTag(st.Tag(i))
+				// Fields that are not sealed do not need accessors
+				if tag.Get("goseal") == "-" {
+					continue
+				}
+				// Fields with other tags are likely used through reflection (e.g. encoding/json)
+				if slices.ContainsFunc(tagKeys(tag), func(key string) bool { return key != "goseal" }) {
+					continue
+				}
+				pos := pkg.Fset.Position(field.Pos())
+				candidates[pos] = FieldAdvice{Position: pos, Package: pkg.PkgPath, Struct: obj.Name(), Field: field.Name()}
+			}
+		}
+	}
+
+	for _, pkg := range pkgs {
+		if pkg.TypesInfo == nil {
+			continue
+		}
+		user := pkg.PkgPath
+		if config.Tests != nil && config.Tests.ExternalTestPackage {
+			user = strings.TrimSuffix(user, "_test")
+		}
+
+		// Uses include selectors and the keys of composite literals
+		for _, obj := range pkg.TypesInfo.Uses {
+			field, ok := obj.(*types.Var)
+			if !ok || !field.IsField() || field.Pkg() == nil || field.Pkg().Path() == user {
+				continue
+			}
+			delete(candidates, pkg.Fset.Position(field.Origin().Pos()))
+		}
+
+		// Unkeyed composite literals use every field without naming them
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				lit, ok := n.(*ast.CompositeLit)
+				if !ok || len(lit.Elts) == 0 {
+					return true
+				}
+				if _, keyed := lit.Elts[0].(*ast.KeyValueExpr); keyed {
+					return true
+				}
+				typ := pkg.TypesInfo.TypeOf(lit)
+				if ptr, ok := typ.(*types.Pointer); ok {
+					typ = ptr.Elem()
+				}
+				if typ == nil {
+					return true
+				}
+				st, ok := typ.Underlying().(*types.Struct)
+				if !ok {
+					return true
+				}
+				for i := range st.NumFields() {
+					field := st.Field(i)
+					if field.Pkg() != nil && field.Pkg().Path() != user {
+						delete(candidates, pkg.Fset.Position(field.Origin().Pos()))
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	advice := make([]FieldAdvice, 0, len(candidates))
+	for _, a := range candidates {
+		advice = append(advice, a)
+	}
+	slices.SortFunc(advice, func(x, y FieldAdvice) int {
+		return cmp.Or(
+			cmp.Compare(x.Position.Filename, y.Position.Filename),
+			cmp.Compare(x.Position.Offset, y.Position.Offset),
+		)
+	})
+	return advice
+}
+
+// tagKeys returns the keys of a struct tag in the conventional key:"value" format.
+func tagKeys(tag reflect.StructTag) []string {
+	var keys []string
+	s := string(tag)
+	for {
+		s = strings.TrimLeft(s, " ")
+		key, rest, ok := strings.Cut(s, ":")
+		if !ok || key == "" {
+			return keys
+		}
+		value, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return keys
+		}
+		keys = append(keys, key)
+		s = rest[len(value):]
+	}
+}
@@ -0,0 +1,19 @@
+package goseal_test
+
+import (
+	"testing"
+
+	"github.com/jimmysharp/goseal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdviseEncapsulation(t *testing.T) {
+	config, pkgs := loadTestdata(t, "advise")
+
+	var fields []string
+	for _, a := range goseal.AdviseEncapsulation(config, pkgs) {
+		require.Equal(t, "example.com/testproject/domain", a.Package)
+		fields = append(fields, a.Struct+"."+a.Field)
+	}
+	require.Equal(t, []string{"Base.Version", "User.Secret", "User.Age"}, fields)
+}
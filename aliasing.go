@@ -0,0 +1,234 @@
+package goseal
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkAliasing reports exported methods of sealed structs that return
+// internal slices, maps or pointers, and exported functions that store
+// slices, maps or pointers supplied by their callers in sealed structs.
+func (c *goseal) checkAliasing(fn *ast.FuncDecl, pass *analysis.Pass, stack []ast.Node) {
+	if !c.config.CheckAliasing || fn.Body == nil || !fn.Name.IsExported() {
+		return
+	}
+
+	c.checkReturnedFields(fn, pass, stack)
+	c.checkStoredParams(fn, pass, stack)
+}
+
+// checkReturnedFields reports return statements of a method that return a
+// reference-typed field of its sealed receiver.
+func (c *goseal) checkReturnedFields(fn *ast.FuncDecl, pass *analysis.Pass, stack []ast.Node) {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 || len(fn.Recv.List[0].Names) == 0 {
+		return
+	}
+	recv := pass.TypesInfo.Defs[fn.Recv.List[0].Names[0]]
+	if recv == nil {
+		return
+	}
+	named := c.sealedStruct(recv.Type())
+	if named == nil {
+		return
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Returns in function literals do not return from the method
+			return false
+		case *ast.ReturnStmt:
+			for _, result := range n.Results {
+				field := c.receiverField(result, recv, pass)
+				if field == nil {
+					continue
+				}
+				kind := c.referenceKind(field.Type())
+				if kind == "" || isUnsealedField(named, field) {
+					continue
+				}
+				c.report(
+					pass,
+					finding{rule: RuleAliasing, node: result, stack: stack, target: qualifiedName(named.Obj()), field: field.Name()},
+					"method %s returns internal %s field %s of sealed struct %s; %s",
+					fn.Name.Name,
+					kind,
+					field.Name(),
+					named.Obj().Name(),
+					copyAdvice("return", kind),
+				)
+			}
+		}
+		return true
+	})
+}
+
+// receiverField returns the field of recv selected by expr, e.g. o.items or o.items[1:].
+func (c *goseal) receiverField(expr ast.Expr, recv types.Object, pass *analysis.Pass) *types.Var {
+	expr = ast.Unparen(expr)
+	if slice, ok := expr.(*ast.SliceExpr); ok {
+		expr = ast.Unparen(slice.X)
+	}
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	x, ok := ast.Unparen(sel.X).(*ast.Ident)
+	if !ok || pass.TypesInfo.Uses[x] != recv {
+		return nil
+	}
+	field, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Var)
+	if !ok || !field.IsField() {
+		return nil
+	}
+	return field
+}
+
+// checkStoredParams reports reference-typed parameters of a function that are
+// stored in fields of sealed structs of the same package, either in composite
+// literals or by assignment.
+func (c *goseal) checkStoredParams(fn *ast.FuncDecl, pass *analysis.Pass, stack []ast.Node) {
+	params := make(map[types.Object]string)
+	for _, field := range fn.Type.Params.List {
+		for _, name := range field.Names {
+			if obj := pass.TypesInfo.Defs[name]; obj != nil {
+				if kind := c.referenceKind(obj.Type()); kind != "" {
+					params[obj] = kind
+				}
+			}
+		}
+	}
+	if len(params) == 0 {
+		return
+	}
+
+	store := func(named *types.Named, field *types.Var, value ast.Expr) {
+		ident, ok := ast.Unparen(value).(*ast.Ident)
+		if !ok {
+			return
+		}
+		param := pass.TypesInfo.Uses[ident]
+		kind, ok := params[param]
+		if !ok || isUnsealedField(named, field) {
+			return
+		}
+		c.report(
+			pass,
+			finding{rule: RuleAliasing, node: value, stack: stack, target: qualifiedName(named.Obj()), field: field.Name()},
+			"function %s stores caller-supplied %s %s in field %s of sealed struct %s; %s",
+			fn.Name.Name,
+			kind,
+			param.Name(),
+			field.Name(),
+			named.Obj().Name(),
+			copyAdvice("store", kind),
+		)
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CompositeLit:
+			named := c.ownSealedStruct(pass.TypesInfo.TypeOf(n), pass)
+			if named == nil {
+				return true
+			}
+			st := named.Underlying().(*types.Struct)
+			for i, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if key, ok := kv.Key.(*ast.Ident); ok {
+						if field, ok := pass.TypesInfo.Uses[key].(*types.Var); ok {
+							store(named, field, kv.Value)
+						}
+					}
+				} else if i < st.NumFields() {
+					store(named, st.Field(i), elt)
+				}
+			}
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+			for i, lhs := range n.Lhs {
+				sel, ok := lhs.(*ast.SelectorExpr)
+				if !ok {
+					continue
+				}
+				named := c.ownSealedStruct(pass.TypesInfo.TypeOf(sel.X), pass)
+				if named == nil {
+					continue
+				}
+				if field, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Var); ok && field.IsField() {
+					store(named, field, n.Rhs[i])
+				}
+			}
+		}
+		return true
+	})
+}
+
+// sealedStruct returns the sealed struct type of typ or of its pointer base, if any.
+func (c *goseal) sealedStruct(typ types.Type) *types.Named {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return nil
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil
+	}
+	if !c.isSealedType(named.Obj()) {
+		return nil
+	}
+	return named
+}
+
+// ownSealedStruct is like sealedStruct, but only returns structs declared in the package of pass.
+func (c *goseal) ownSealedStruct(typ types.Type, pass *analysis.Pass) *types.Named {
+	if typ == nil {
+		return nil
+	}
+	named := c.sealedStruct(typ)
+	if named == nil || named.Obj().Pkg() != pass.Pkg {
+		return nil
+	}
+	return named
+}
+
+// referenceKind returns "slice", "map" or "pointer" for types whose values
+// share their contents when copied, or "" otherwise. Pointers to sealed
+// structs are not reported, since the structs protect themselves.
+func (c *goseal) referenceKind(typ types.Type) string {
+	switch t := typ.Underlying().(type) {
+	case *types.Slice:
+		return "slice"
+	case *types.Map:
+		return "map"
+	case *types.Pointer:
+		if c.sealedStruct(t) != nil {
+			return ""
+		}
+		return "pointer"
+	}
+	return ""
+}
+
+// isUnsealedField reports whether field is tagged goseal:"-" in the struct named.
+func isUnsealedField(named *types.Named, field *types.Var) bool {
+	tag, ok := fieldTag(named, field)
+	return ok && tag == "-"
+}
+
+func copyAdvice(verb, kind string) string {
+	switch kind {
+	case "slice":
+		return verb + " a copy with slices.Clone"
+	case "map":
+		return verb + " a copy with maps.Clone"
+	default:
+		return verb + " a pointer to a copy of the value"
+	}
+}
@@ -0,0 +1,306 @@
+package goseal
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// policy is the result of the policy analyzer: what the checks of a package
+// share, so that it is resolved once however many checks run.
+type policy struct {
+	skip      bool                     // Nothing in the package is checked
+	skipFiles map[*ast.File]bool       // Files that are not checked
+	fileRules map[*token.File][]string // Rules disabled in individual files
+	writes    receiverWrites           // Pointer-receiver methods used in the package, and whether they write their receiver
+}
+
+// check is a group of rules reported by one analyzer of NewAnalyzers. Its
+// visit function ignores nodes of other types, so that NewAnalyzer can pass
+// every node to all checks.
+type check struct {
+	name  string
+	doc   string
+	nodes []ast.Node // Node types passed to visit
+	visit func(c *goseal, pass *analysis.Pass, p *policy, n ast.Node, stack []ast.Node)
+}
+
+var checks = []check{
+	{
+		name:  "goseal_init",
+		doc:   "Checks that sealed structs are only constructed in the allowed scope and factory functions",
+		nodes: []ast.Node{(*ast.CompositeLit)(nil), (*ast.FuncDecl)(nil)},
+		visit: func(c *goseal, pass *analysis.Pass, _ *policy, n ast.Node, stack []ast.Node) {
+			switch n := n.(type) {
+			case *ast.CompositeLit:
+				c.checkCompositeLit(n, pass, stack)
+			case *ast.FuncDecl:
+				c.checkFactoryValidation(n, pass, stack)
+			}
+		},
+	},
+	{
+		name:  "goseal_mutation",
+		doc:   "Checks that fields of sealed structs are only written in the allowed scope, and that exported variables do not hold sealed structs",
+		nodes: []ast.Node{(*ast.AssignStmt)(nil), (*ast.CallExpr)(nil), (*ast.TypeSpec)(nil), (*ast.FuncDecl)(nil), (*ast.ValueSpec)(nil)},
+		visit: func(c *goseal, pass *analysis.Pass, p *policy, n ast.Node, stack []ast.Node) {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				c.checkAssignStmt(n, pass, stack)
+			case *ast.CallExpr:
+				c.checkMethodCall(n, pass, stack, p.writes)
+			case *ast.TypeSpec:
+				c.checkFieldTags(n, pass, stack)
+			case *ast.FuncDecl:
+				c.checkInvariants(n, pass, stack, p.writes)
+			case *ast.ValueSpec:
+				c.checkExportedVars(n, pass, stack)
+			}
+		},
+	},
+	{
+		name:  "goseal_restricted",
+		doc:   "Checks that restricted functions are only called or used by their allowed callers",
+		nodes: []ast.Node{(*ast.CallExpr)(nil), (*ast.Ident)(nil)},
+		visit: func(c *goseal, pass *analysis.Pass, _ *policy, n ast.Node, stack []ast.Node) {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				c.checkCallExpr(n, pass, stack)
+			case *ast.Ident:
+				c.checkFuncValue(n, pass, stack)
+			}
+		},
+	},
+	{
+		name:  "goseal_aliasing",
+		doc:   "Checks that sealed structs do not share slices, maps or pointers with their callers",
+		nodes: []ast.Node{(*ast.FuncDecl)(nil)},
+		visit: func(c *goseal, pass *analysis.Pass, _ *policy, n ast.Node, stack []ast.Node) {
+			if fn, ok := n.(*ast.FuncDecl); ok {
+				c.checkAliasing(fn, pass, stack)
+			}
+		},
+	},
+	{
+		name: "goseal_copy",
+		doc:  "Checks that sealed structs are only copied by value in the allowed scope",
+		nodes: []ast.Node{
+			(*ast.AssignStmt)(nil),
+			(*ast.ValueSpec)(nil),
+			(*ast.CallExpr)(nil),
+			(*ast.ReturnStmt)(nil),
+			(*ast.CompositeLit)(nil),
+			(*ast.SendStmt)(nil),
+			(*ast.RangeStmt)(nil),
+		},
+		visit: func(c *goseal, pass *analysis.Pass, _ *policy, n ast.Node, stack []ast.Node) {
+			c.checkCopy(n, pass, stack)
+		},
+	},
+}
+
+// NewAnalyzers returns the checks of NewAnalyzer as separate analyzers, named
+// after the rules they report (e.g. goseal_init, goseal_mutation), so that
+// drivers such as multichecker can toggle them individually. The last
+// analyzer, goseal, requires all others; it owns the flags and reports
+// stale baseline entries.
+func NewAnalyzers(config *Config, opts ...Option) []*analysis.Analyzer {
+	c := newGoseal(config, opts...)
+
+	var analyzers []*analysis.Analyzer
+	for _, ch := range checks {
+		analyzers = append(analyzers, c.checkAnalyzer(ch))
+	}
+
+	a := c.analyzer("Reports baseline entries no longer matching a violation of the goseal analyzers", c.runBaseline)
+	a.Requires = append(a.Requires, analyzers...)
+	return append(analyzers, a)
+}
+
+func newGoseal(config *Config, opts ...Option) *goseal {
+	c := &goseal{
+		config: config,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.policyAnalyzer = &analysis.Analyzer{
+		Name:       "goseal_policy",
+		Doc:        "Resolves the goseal configuration for each package; required by the goseal analyzers",
+		URL:        rulesDocURL,
+		Run:        c.runPolicy,
+		ResultType: reflect.TypeFor[*policy](),
+	}
+	// Without facts, drivers need not load and analyze the syntax of dependencies
+	if c.configFlag || config.usesReceiverWrites() {
+		c.policyAnalyzer.FactTypes = []analysis.Fact{new(readOnlyMethodFact)}
+	}
+	return c
+}
+
+// analyzer returns the goseal analyzer with the given doc and run function,
+// which carries the flags.
+func (c *goseal) analyzer(doc string, run func(*analysis.Pass) (any, error)) *analysis.Analyzer {
+	a := &analysis.Analyzer{
+		Name: "goseal",
+		Doc:  doc,
+		URL:  rulesDocURL,
+		Run:  run,
+
+		Requires: []*analysis.Analyzer{c.policyAnalyzer, inspect.Analyzer},
+	}
+	if c.configFlag {
+		a.Flags.StringVar(&c.configPath, "config", "", "path to the config file (default: the nearest .goseal.yml)")
+	}
+	a.Flags.StringVar(&c.baseline.readPath, "baseline", "", "report only violations not recorded in this baseline file")
+	a.Flags.StringVar(&c.baseline.writePath, "write-baseline", "", "record all current violations to this baseline file instead of reporting them")
+	return a
+}
+
+func (c *goseal) checkAnalyzer(ch check) *analysis.Analyzer {
+	var codes []string
+	for _, rule := range Rules() {
+		if rule.Analyzer == ch.name {
+			codes = append(codes, rule.Code)
+		}
+	}
+
+	return &analysis.Analyzer{
+		Name: ch.name,
+		Doc:  ch.doc + " (" + strings.Join(codes, ", ") + ")",
+		URL:  rulesDocURL,
+		Run: func(pass *analysis.Pass) (any, error) {
+			p := c.policy(pass)
+			if !p.skip {
+				c.inspect(pass, p, ch.nodes, func(n ast.Node, stack []ast.Node) {
+					ch.visit(c, pass, p, n, stack)
+				})
+			}
+			return nil, nil
+		},
+
+		Requires: []*analysis.Analyzer{c.policyAnalyzer, inspect.Analyzer},
+	}
+}
+
+// policy returns the result of the policy analyzer for pass.
+func (c *goseal) policy(pass *analysis.Pass) *policy {
+	return pass.ResultOf[c.policyAnalyzer].(*policy)
+}
+
+// inspect calls visit for the nodes of the given types in the checked files of pass.
+func (c *goseal) inspect(pass *analysis.Pass, p *policy, nodes []ast.Node, visit func(n ast.Node, stack []ast.Node)) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := append([]ast.Node{(*ast.File)(nil)}, nodes...)
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		if f, ok := n.(*ast.File); ok {
+			return !p.skipFiles[f]
+		}
+		visit(n, stack)
+		return true
+	})
+}
+
+func (c *goseal) runPolicy(pass *analysis.Pass) (any, error) {
+	if c.configFlag {
+		if err := c.loadConfig(pass); err != nil {
+			return nil, err
+		}
+	}
+
+	p := &policy{
+		skipFiles: make(map[*ast.File]bool),
+		fileRules: make(map[*token.File][]string),
+	}
+
+	// Facts are needed for all packages, including generated code and dependencies
+	usesWrites := c.config.usesReceiverWrites()
+	if usesWrites {
+		p.writes = c.analyzeReceiverWrites(pass)
+	}
+	if isDependency(pass) {
+		p.skip = true
+		return p, nil
+	}
+	// Facts are only visible to this analyzer, so resolve those of the
+	// imported methods here for the checks
+	if usesWrites {
+		importReceiverWrites(pass, p.writes)
+	}
+
+	// Decide once per file which files to check, instead of for every node
+	skippedGenerated := 0
+	for _, f := range pass.Files {
+		tf := pass.Fset.File(f.Pos())
+		var generatedRules []string
+		if name, ok := generator(f); ok {
+			check, rules := c.generatedPolicy(name)
+			if !check {
+				p.skipFiles[f] = true
+				skippedGenerated++
+				continue
+			}
+			generatedRules = rules
+		}
+		ignoredRules, ignored := c.ignoreFile(tf)
+		if ignored {
+			p.skipFiles[f] = true
+			continue
+		}
+		if disabled := slices.Concat(generatedRules, ignoredRules); len(disabled) > 0 {
+			p.fileRules[tf] = disabled
+		}
+	}
+
+	// If all files are generated and skipped, skip this package
+	if skippedGenerated == len(pass.Files) {
+		p.skip = true
+		return p, nil
+	}
+
+	if c.baseline.enabled() {
+		if err := c.baseline.load(); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// importReceiverWrites adds the pointer-receiver methods of other packages
+// used in pass to writes, according to their facts.
+func importReceiverWrites(pass *analysis.Pass, writes receiverWrites) {
+	for _, obj := range pass.TypesInfo.Uses {
+		fn, ok := obj.(*types.Func)
+		if !ok || !hasPointerReceiver(fn) {
+			continue
+		}
+		fn = fn.Origin()
+		if fn.Pkg() == pass.Pkg {
+			continue
+		}
+		if _, ok := writes[fn]; !ok {
+			writes[fn] = !pass.ImportObjectFact(fn, new(readOnlyMethodFact))
+		}
+	}
+}
+
+// runBaseline finishes the baseline once all analyzers of NewAnalyzers have
+// checked the package.
+func (c *goseal) runBaseline(pass *analysis.Pass) (any, error) {
+	if c.policy(pass).skip || !c.baseline.enabled() {
+		return nil, nil
+	}
+	return nil, c.finishBaseline(pass)
+}
@@ -0,0 +1,384 @@
+package goseal
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const baselineVersion = 1
+
+// Baseline records known violations so that only new ones are reported.
+type Baseline struct {
+	Version int             `json:"version"`
+	Entries []BaselineEntry `json:"entries"`
+}
+
+// BaselineEntry identifies violations by a line-independent fingerprint.
+// Count is the number of identical violations with this fingerprint.
+type BaselineEntry struct {
+	Package  string `json:"package"`
+	Function string `json:"function"`
+	Struct   string `json:"struct"`
+	Field    string `json:"field,omitempty"`
+	Code     string `json:"code"`
+	Count    int    `json:"count"`
+}
+
+type fingerprint struct {
+	Package  string
+	Function string
+	Struct   string
+	Field    string
+	Code     string
+}
+
+func (fp fingerprint) entry(count int) BaselineEntry {
+	return BaselineEntry{
+		Package:  fp.Package,
+		Function: fp.Function,
+		Struct:   fp.Struct,
+		Field:    fp.Field,
+		Code:     fp.Code,
+		Count:    count,
+	}
+}
+
+func (e BaselineEntry) fingerprint() fingerprint {
+	return fingerprint{
+		Package:  e.Package,
+		Function: e.Function,
+		Struct:   e.Struct,
+		Field:    e.Field,
+		Code:     e.Code,
+	}
+}
+
+// ReadBaseline reads a baseline file written by WriteBaseline.
+func ReadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline file %s: %w", path, err)
+	}
+	if b.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d in %s (want %d)", b.Version, path, baselineVersion)
+	}
+	return &b, nil
+}
+
+// WriteBaseline writes b to path with entries in a stable order.
+func WriteBaseline(path string, b *Baseline) error {
+	b.Version = baselineVersion
+	slices.SortFunc(b.Entries, func(x, y BaselineEntry) int {
+		return cmp.Or(
+			cmp.Compare(x.Package, y.Package),
+			cmp.Compare(x.Function, y.Function),
+			cmp.Compare(x.Struct, y.Struct),
+			cmp.Compare(x.Field, y.Field),
+			cmp.Compare(x.Code, y.Code),
+		)
+	})
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// baselineLockTimeout is how long a write waits for another process holding
+// the lock of the baseline file.
+const baselineLockTimeout = time.Minute
+
+// baselineState holds the baseline shared by all passes of the analyzers.
+// Variants of the same package (e.g. with and without test files) may be
+// analyzed concurrently, so occurrences are counted per type-checked
+// package, which all analyzers of a variant share.
+//
+// Drivers such as go vet analyze each package in a separate process, so a
+// written baseline is merged into the existing file: only the entries of
+// functions analyzed by this process are replaced.
+type baselineState struct {
+	readPath  string // -baseline flag
+	writePath string // -write-baseline flag
+
+	loadOnce sync.Once
+	loadErr  error
+	known    map[fingerprint]int
+
+	mu       sync.Mutex
+	seen     map[*types.Package]map[fingerprint]int
+	recorded map[fingerprint]int
+	deferred map[string][]map[string]bool // Functions each pass in write mode left to other variants, by package path
+}
+
+func (b *baselineState) enabled() bool {
+	return b.readPath != "" || b.writePath != ""
+}
+
+func (b *baselineState) load() error {
+	b.loadOnce.Do(func() {
+		b.seen = make(map[*types.Package]map[fingerprint]int)
+		b.recorded = make(map[fingerprint]int)
+		b.deferred = make(map[string][]map[string]bool)
+		b.known = make(map[fingerprint]int)
+		if b.readPath == "" {
+			return
+		}
+
+		baseline, err := ReadBaseline(b.readPath)
+		if err != nil {
+			b.loadErr = err
+			return
+		}
+		for _, e := range baseline.Entries {
+			b.known[e.fingerprint()] += e.Count
+		}
+	})
+	return b.loadErr
+}
+
+// observe records a violation and reports whether it should be suppressed.
+func (b *baselineState) observe(pass *analysis.Pass, fp fingerprint) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	counts := b.seen[pass.Pkg]
+	if counts == nil {
+		counts = make(map[fingerprint]int)
+		b.seen[pass.Pkg] = counts
+	}
+	counts[fp]++
+
+	if b.writePath != "" {
+		return true
+	}
+	return counts[fp] <= b.known[fp]
+}
+
+// finish completes the pass: in write mode it merges the pass into the
+// baseline file, otherwise it returns the baseline entries of the pass's
+// package that no longer match any violation. Entries of functions in
+// elsewhere, which only another variant of the package declares, are left
+// to that variant.
+func (b *baselineState) finish(pass *analysis.Pass, declared map[string]token.Pos, elsewhere map[string]bool) ([]BaselineEntry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	counts := b.seen[pass.Pkg]
+	delete(b.seen, pass.Pkg)
+
+	if b.writePath != "" {
+		for fp, n := range counts {
+			b.recorded[fp] = max(b.recorded[fp], n)
+		}
+		b.deferred[pass.Pkg.Path()] = append(b.deferred[pass.Pkg.Path()], elsewhere)
+		return nil, b.writeRecorded()
+	}
+
+	var fixed []BaselineEntry
+	for fp, n := range b.known {
+		if fp.Package != pass.Pkg.Path() {
+			continue
+		}
+		// Entries of functions in another variant of the package (e.g.
+		// in its test files) cannot be judged here, but those of
+		// functions declared nowhere have been fixed by removing them.
+		if _, ok := declared[fp.Function]; !ok && elsewhere[fp.Function] {
+			continue
+		}
+		if counts[fp] < n {
+			fixed = append(fixed, fp.entry(n-counts[fp]))
+		}
+	}
+	slices.SortFunc(fixed, func(x, y BaselineEntry) int {
+		return cmp.Or(
+			cmp.Compare(declared[x.Function], declared[y.Function]),
+			cmp.Compare(x.Struct, y.Struct),
+			cmp.Compare(x.Field, y.Field),
+			cmp.Compare(x.Code, y.Code),
+		)
+	})
+	return fixed, nil
+}
+
+// writeRecorded merges everything recorded so far into the baseline file, so
+// the file is complete once the last package has been analyzed, whether by
+// this process or another. Entries of packages not analyzed by this process,
+// and of functions only another variant of them declares, are kept as they
+// are.
+func (b *baselineState) writeRecorded() error {
+	unlock, err := lockBaseline(b.writePath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	merged := make(map[fingerprint]int)
+	existing, err := ReadBaseline(b.writePath)
+	switch {
+	case err == nil:
+		for _, e := range existing.Entries {
+			if b.deferredByAll(e) {
+				merged[e.fingerprint()] = e.Count
+			}
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+	for fp, n := range b.recorded {
+		merged[fp] = n
+	}
+
+	baseline := &Baseline{Entries: []BaselineEntry{}}
+	for fp, n := range merged {
+		baseline.Entries = append(baseline.Entries, fp.entry(n))
+	}
+	return WriteBaseline(b.writePath, baseline)
+}
+
+// deferredByAll reports whether every pass of e's package in write mode left
+// e's function to another variant, or none analyzed the package.
+func (b *baselineState) deferredByAll(e BaselineEntry) bool {
+	for _, elsewhere := range b.deferred[e.Package] {
+		if !elsewhere[e.Function] {
+			return false
+		}
+	}
+	return true
+}
+
+// lockBaseline locks the baseline file at path against other processes by
+// creating a lock file next to it, and returns the function removing it.
+func lockBaseline(path string) (func(), error) {
+	lock := path + ".lock"
+	deadline := time.Now().Add(baselineLockTimeout)
+	for {
+		f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lock) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("failed to lock baseline file: %w", err)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for the lock %s of the baseline file; remove it if no goseal process is running", lock)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// declaredFunctions returns the baseline function keys declared in files,
+// mapped to the position of their name.
+func declaredFunctions(files []*ast.File) map[string]token.Pos {
+	declared := make(map[string]token.Pos)
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if _, ok := declared[funcDeclKey(decl)]; !ok {
+					declared[funcDeclKey(decl)] = decl.Name.Pos()
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					if vs, ok := spec.(*ast.ValueSpec); ok {
+						declared[valueSpecKey(vs)] = vs.Pos()
+					}
+				}
+			}
+		}
+	}
+	return declared
+}
+
+// otherVariantFunctions returns the baseline function keys that other
+// variants of pass's package may declare: those of the package's test files
+// not in pass and of its files excluded by build constraints. If there are
+// such files, it includes the empty key of violations outside functions.
+func otherVariantFunctions(pass *analysis.Pass) map[string]bool {
+	inPass := make(map[string]bool)
+	for _, f := range pass.Files {
+		inPass[pass.Fset.File(f.Pos()).Name()] = true
+	}
+
+	paths := slices.Clone(pass.IgnoredFiles)
+	for dir := range inPass {
+		tests, _ := filepath.Glob(filepath.Join(filepath.Dir(dir), "*_test.go"))
+		for _, path := range tests {
+			if !inPass[path] && !slices.Contains(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, path := range paths {
+		if !strings.HasSuffix(path, ".go") {
+			continue
+		}
+		// Partially parsed files still declare what they can
+		f, _ := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if f != nil && f.Name.Name == pass.Pkg.Name() {
+			files = append(files, f)
+		}
+	}
+
+	elsewhere := make(map[string]bool)
+	for fn := range declaredFunctions(files) {
+		elsewhere[fn] = true
+	}
+	if len(files) > 0 {
+		elsewhere[""] = true
+	}
+	return elsewhere
+}
+
+// enclosingFunctionKey returns the function part of a fingerprint for a node
+// with the given stack: the enclosing function or method, or the package-level
+// variable declaration.
+func enclosingFunctionKey(stack []ast.Node) string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncDecl:
+			return funcDeclKey(n)
+		case *ast.ValueSpec:
+			// Package-level declarations are at [*ast.File, *ast.GenDecl, *ast.ValueSpec]
+			if i == 2 {
+				return valueSpecKey(n)
+			}
+		}
+	}
+	return ""
+}
+
+func funcDeclKey(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	return "(" + types.ExprString(fn.Recv.List[0].Type) + ")." + fn.Name.Name
+}
+
+func valueSpecKey(vs *ast.ValueSpec) string {
+	return "var " + vs.Names[0].Name
+}
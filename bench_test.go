@@ -0,0 +1,240 @@
+package goseal_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jimmysharp/goseal"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// benchConfig mirrors the configuration of a large monorepo, with several
+// patterns for each matcher.
+const benchConfig = `target-packages:
+  - "example\\.com/bench/billing/"
+  - "example\\.com/bench/domain[0-9]+$"
+  - "example\\.com/bench/inventory/"
+exclude-structs:
+  - "DTO$"
+  - "^Raw"
+  - "Params$"
+factory-names:
+  - "^New"
+  - "^Restore"
+ignore-files:
+  - "_mock\\.go$"
+  - "/mocks/"
+  - "\\.pb\\.go$"
+mutation-scope: receiver
+`
+
+func BenchmarkAnalyzer(b *testing.B) {
+	for _, size := range []int{10, 100} {
+		b.Run(fmt.Sprintf("packages=%d", size), func(b *testing.B) {
+			files := make(map[string]string)
+			for i := range size {
+				files[fmt.Sprintf("domain%d/domain.go", i)] = benchDomainPackage
+				files[fmt.Sprintf("app%d/app.go", i)] = strings.ReplaceAll(benchAppPackage, "DOMAIN", fmt.Sprintf("domain%d", i))
+			}
+			config, pkgs := loadBenchModule(b, benchConfig, files)
+			runBenchmark(b, config, pkgs)
+		})
+	}
+}
+
+// BenchmarkAnalyzer_Stdlib measures a module importing the standard library,
+// whose packages are analyzed too when the receiver write facts are needed.
+func BenchmarkAnalyzer_Stdlib(b *testing.B) {
+	for _, tt := range []struct {
+		name   string
+		config string
+	}{
+		{name: "facts", config: benchConfig},
+		{name: "no-facts", config: benchConfig + "disabled-rules:\n  - GS003\n"},
+	} {
+		b.Run(tt.name, func(b *testing.B) {
+			config, pkgs := loadBenchModule(b, tt.config, map[string]string{
+				"domain1/domain.go": benchStdlibPackage,
+			})
+			runBenchmark(b, config, pkgs)
+		})
+	}
+}
+
+// runBenchmark analyzes pkgs and all their dependencies, as drivers do for
+// analyzers using facts.
+func runBenchmark(b *testing.B, config *goseal.Config, pkgs []*packages.Package) {
+	b.Helper()
+
+	b.ResetTimer()
+	for b.Loop() {
+		a := goseal.NewAnalyzer(config)
+		graph, err := checker.Analyze([]*analysis.Analyzer{a}, pkgs, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, act := range graph.Roots {
+			if act.Err != nil {
+				b.Fatal(act.Err)
+			}
+		}
+	}
+}
+
+// loadBenchModule writes a synthetic module with the given configuration and
+// files, and loads it.
+func loadBenchModule(b *testing.B, configYAML string, files map[string]string) (*goseal.Config, []*packages.Package) {
+	b.Helper()
+
+	dir := b.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			b.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	write("go.mod", "module example.com/bench\n\ngo 1.24\n")
+	write(".goseal.yml", configYAML)
+	for name, content := range files {
+		write(name, content)
+	}
+
+	config, err := goseal.ParseConfig(filepath.Join(dir, ".goseal.yml"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: dir}, "./...")
+	if err != nil {
+		b.Fatal(err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		b.Fatal("failed to load benchmark module")
+	}
+	return config, pkgs
+}
+
+const benchDomainPackage = `package domain
+
+type User struct {
+	ID    int
+	Name  string
+	Email string
+	Tags  []string
+}
+
+func NewUser(id int, name, email string) *User {
+	return &User{ID: id, Name: name, Email: email}
+}
+
+func (u *User) Rename(name string) {
+	u.Name = name
+}
+
+func (u *User) AddTag(tag string) {
+	u.Tags = append(u.Tags, tag)
+}
+
+type Order struct {
+	ID    int
+	Owner *User
+	Lines []Line
+	Total int
+}
+
+type Line struct {
+	SKU   string
+	Count int
+}
+
+func NewOrder(id int, owner *User, lines []Line) *Order {
+	o := &Order{ID: id, Owner: owner}
+	for _, l := range lines {
+		o.Lines = append(o.Lines, Line{SKU: l.SKU, Count: l.Count})
+		o.Total += l.Count
+	}
+	return o
+}
+
+type UserDTO struct {
+	ID   int
+	Name string
+}
+
+func (u *User) ToDTO() UserDTO {
+	return UserDTO{ID: u.ID, Name: u.Name}
+}
+`
+
+const benchAppPackage = `package app
+
+import "example.com/bench/DOMAIN"
+
+func Run() []*domain.Order {
+	u := domain.NewUser(1, "alice", "alice@example.com")
+	u.Rename("bob")
+	u.AddTag("admin")
+
+	var orders []*domain.Order
+	for i := range 20 {
+		lines := []domain.Line{{SKU: "a", Count: i}, {SKU: "b", Count: i * 2}}
+		orders = append(orders, domain.NewOrder(i, u, lines))
+	}
+
+	dto := domain.UserDTO{ID: u.ID, Name: u.Name}
+	dto.Name = "carol"
+
+	// Violations
+	u.Name = "mallory"
+	_ = &domain.User{ID: 2}
+	orders[0].Total = 0
+	return orders
+}
+`
+
+const benchStdlibPackage = `package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type User struct {
+	ID   int
+	Name string
+	Buf  bytes.Buffer
+}
+
+func NewUser(id int, name string) *User {
+	return &User{ID: id, Name: name}
+}
+
+func (u *User) Render() string {
+	var sb strings.Builder
+	sb.WriteString(u.Name)
+	data, _ := json.Marshal(u.ID)
+	sb.Write(data)
+	return sb.String()
+}
+
+func Fetch(url string) (*User, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	u := NewUser(0, "")
+	err = json.NewDecoder(resp.Body).Decode(u)
+	return u, err
+}
+`
@@ -0,0 +1,22 @@
+// Command goseal-vet runs goseal as a vet tool, so that the go command
+// analyzes packages in parallel and caches the results:
+//
+//	go vet -vettool=$(which goseal-vet) ./...
+//
+// The configuration is read from the file given by -goseal.config, or else
+// from the nearest .goseal.yml in the directory of each package or its
+// parents up to the module root. The go command runs vet tools in the
+// directory of each package, so -goseal.config should be an absolute path,
+// as should -goseal.baseline and -goseal.write-baseline. A baseline written
+// by the process of each package is merged into the file.
+// Individual analyzers can be disabled, e.g. with -goseal_copy=false.
+package main
+
+import (
+	"github.com/jimmysharp/goseal"
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+func main() {
+	unitchecker.Main(goseal.NewAnalyzers(nil, goseal.WithConfigFlag())...)
+}
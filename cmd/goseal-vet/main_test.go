@@ -0,0 +1,122 @@
+package main_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jimmysharp/goseal"
+	"github.com/jimmysharp/goseal/internal/analyzertest"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVetTool checks that go vet with goseal-vet reports the same diagnostics
+// as running the analyzer directly.
+func TestVetTool(t *testing.T) {
+	tool := filepath.Join(t.TempDir(), "goseal-vet")
+	out, err := exec.Command("go", "build", "-o", tool, ".").CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	dir, err := filepath.Abs(filepath.Join("..", "..", "testdata", "basic"))
+	require.NoError(t, err)
+
+	t.Run("discovered config", func(t *testing.T) {
+		require.Equal(t, analyze(t, dir, filepath.Join(dir, ".goseal.yml")), vet(t, dir, tool))
+	})
+
+	t.Run("config flag", func(t *testing.T) {
+		config := filepath.Join(t.TempDir(), "goseal.yml")
+		require.NoError(t, os.WriteFile(config, []byte("target-packages:\n  - \"example\\\\.com/testproject/domain\"\ninit-scope: any\n"), 0o644))
+		require.Equal(t, analyze(t, dir, config), vet(t, dir, tool, "-goseal.config="+config))
+	})
+
+	t.Run("write baseline", func(t *testing.T) {
+		// Each package is analyzed by a separate process, which merges its
+		// entries into the file
+		path := filepath.Join(t.TempDir(), "baseline.json")
+		other := goseal.BaselineEntry{Package: "example.com/other", Function: "F", Struct: "example.com/other.T", Code: "GS002", Count: 1}
+		require.NoError(t, goseal.WriteBaseline(path, &goseal.Baseline{Entries: []goseal.BaselineEntry{
+			other,
+			{Package: "example.com/testproject/app", Function: "WithFactoryFunction", Struct: "example.com/testproject/domain.User", Code: "GS002", Count: 1},
+			{Package: "example.com/testproject/app", Function: "Removed", Struct: "example.com/testproject/domain.User", Code: "GS002", Count: 1},
+		}}))
+		require.Empty(t, vet(t, dir, tool, "-goseal.write-baseline="+path))
+
+		got, err := goseal.ReadBaseline(path)
+		require.NoError(t, err)
+		want := writeBaseline(t, dir, filepath.Join(dir, ".goseal.yml"))
+		require.ElementsMatch(t, append(want.Entries, other), got.Entries)
+		require.ElementsMatch(t, []string{"example.com/other", "example.com/testproject/app", "example.com/testproject/domain"}, baselinePackages(got))
+
+		require.Empty(t, vet(t, dir, tool, "-goseal.baseline="+path))
+	})
+}
+
+// vet runs go vet with the vet tool over the module in dir and returns the
+// diagnostics, sorted.
+func vet(t *testing.T, dir, tool string, flags ...string) []string {
+	t.Helper()
+
+	args := append([]string{"vet", "-vettool=" + tool}, flags...)
+	cmd := exec.Command("go", append(args, "./...")...)
+	cmd.Dir = dir
+	out, _ := cmd.CombinedOutput()
+	var diagnostics []string
+	for line := range strings.Lines(string(out)) {
+		if !strings.HasPrefix(line, "#") {
+			diagnostics = append(diagnostics, strings.TrimSpace(line))
+		}
+	}
+	slices.Sort(diagnostics)
+	return diagnostics
+}
+
+// analyze runs the analyzer over the module in dir, configured by the file
+// configPath, and returns the diagnostics formatted like go vet, sorted.
+func analyze(t *testing.T, dir, configPath string) []string {
+	t.Helper()
+
+	config, err := goseal.ParseConfig(configPath)
+	require.NoError(t, err)
+
+	var diagnostics []string
+	for _, d := range analyzertest.Run(t, dir, "./...", goseal.NewAnalyzer(config)) {
+		rel, err := filepath.Rel(dir, d.Position.Filename)
+		require.NoError(t, err)
+		diagnostics = append(diagnostics, fmt.Sprintf("%s:%d:%d: %s", rel, d.Position.Line, d.Position.Column, d.Message))
+	}
+	slices.Sort(diagnostics)
+	return slices.Compact(diagnostics)
+}
+
+// writeBaseline runs the analyzer over the module in dir, configured by the
+// file configPath, and returns the baseline it writes.
+func writeBaseline(t *testing.T, dir, configPath string) *goseal.Baseline {
+	t.Helper()
+
+	config, err := goseal.ParseConfig(configPath)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	a := goseal.NewAnalyzer(config)
+	require.NoError(t, a.Flags.Set("write-baseline", path))
+	require.Empty(t, analyzertest.Run(t, dir, "./...", a))
+
+	baseline, err := goseal.ReadBaseline(path)
+	require.NoError(t, err)
+	return baseline
+}
+
+// baselinePackages returns the packages with entries in b.
+func baselinePackages(b *goseal.Baseline) []string {
+	var pkgs []string
+	for _, e := range b.Entries {
+		pkgs = append(pkgs, e.Package)
+	}
+	slices.Sort(pkgs)
+	return slices.Compact(pkgs)
+}
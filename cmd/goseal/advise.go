@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jimmysharp/goseal"
+)
+
+// runAdvise implements "goseal advise" and returns the exit code.
+// Advice is not a violation, so the exit code is 0 even when advice is given.
+func runAdvise(args []string) int {
+	fs := flag.NewFlagSet("advise", flag.ContinueOnError)
+	configPath := fs.String("config", ".goseal.yml", "path to the config file")
+	format := fs.String("format", "text", "output format: "+strings.Join(formatNames(), ", "))
+	tests := fs.Bool("test", true, "indicates whether test files should be analyzed, too")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	write, ok := formats[*format]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "goseal advise: unknown format %q (must be one of %s)\n", *format, strings.Join(formatNames(), ", "))
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "goseal advise: no packages given")
+		return 2
+	}
+
+	findings, err := advise(*configPath, fs.Args(), *tests)
+	if err == nil {
+		err = write(os.Stdout, findings)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goseal advise: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func advise(configPath string, patterns []string, tests bool) ([]finding, error) {
+	config, err := goseal.ParseConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, err := loadPackages(patterns, tests, false)
+	if err != nil {
+		return nil, err
+	}
+
+	cwd, _ := os.Getwd()
+	var findings []finding
+	for _, a := range goseal.AdviseEncapsulation(config, pkgs) {
+		findings = append(findings, finding{Rule: goseal.RuleEncapsulation, Pos: relativePosition(cwd, a.Position), Message: a.Message()})
+	}
+	return findings, nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/goccy/go-yaml"
+	"github.com/jimmysharp/goseal"
+)
+
+const configUsage = `usage: goseal config <command> [flags]
+
+Commands:
+  validate  check that the config file is valid
+  print     print the effective config after defaults are applied
+  schema    print the JSON Schema for .goseal.yml
+`
+
+// runConfig implements the "goseal config" subcommands and returns the exit code.
+func runConfig(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, configUsage)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "validate":
+		err = runConfigValidate(args[1:])
+	case "print":
+		err = runConfigPrint(args[1:])
+	case "schema":
+		err = runConfigSchema(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "goseal config: unknown command %q\n\n%s", args[0], configUsage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goseal config %s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+func runConfigValidate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	path := fs.String("config", ".goseal.yml", "path to the config file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(*path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if _, err := goseal.ParseFromYAML(data); err != nil {
+		return fmt.Errorf("%s: %w", *path, err)
+	}
+
+	fmt.Printf("%s: ok\n", *path)
+	return nil
+}
+
+func runConfigPrint(args []string) error {
+	fs := flag.NewFlagSet("print", flag.ContinueOnError)
+	path := fs.String("config", ".goseal.yml", "path to the config file")
+	format := fs.String("format", "yaml", "output format (yaml or json)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	config, err := goseal.ParseConfig(*path)
+	if err != nil {
+		return err
+	}
+
+	var out []byte
+	switch *format {
+	case "yaml":
+		out, err = yaml.MarshalWithOptions(config, yaml.UseJSONMarshaler())
+	case "json":
+		out, err = json.MarshalIndent(config, "", "  ")
+		out = append(out, '\n')
+	default:
+		return fmt.Errorf("unknown format %q (must be 'yaml' or 'json')", *format)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(out)
+	return err
+}
+
+func runConfigSchema(args []string) error {
+	fs := flag.NewFlagSet("schema", flag.ContinueOnError)
+	output := fs.String("o", "", "write the schema to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	schema, err := goseal.JSONSchema()
+	if err != nil {
+		return err
+	}
+
+	if *output != "" {
+		return os.WriteFile(*output, schema, 0o644)
+	}
+	_, err = os.Stdout.Write(schema)
+	return err
+}
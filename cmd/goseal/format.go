@@ -0,0 +1,331 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/jimmysharp/goseal"
+)
+
+type formatFunc func(w io.Writer, findings []finding) error
+
+var formats = map[string]formatFunc{
+	"sarif":      writeSARIF,
+	"checkstyle": writeCheckstyle,
+	"junit":      writeJUnit,
+	"github":     writeGitHub,
+	"markdown":   writeMarkdown,
+	"text":       writeText,
+}
+
+func formatNames() []string {
+	return slices.Sorted(maps.Keys(formats))
+}
+
+// writeText writes the findings like go vet does.
+func writeText(w io.Writer, findings []finding) error {
+	for _, f := range findings {
+		if _, err := fmt.Fprintf(w, "%s: %s\n", f.Pos, f.Message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SARIF 2.1.0 (https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+func writeSARIF(w io.Writer, findings []finding) error {
+	rules := goseal.Rules()
+	driver := sarifDriver{
+		Name:           "goseal",
+		InformationURI: "https://github.com/jimmysharp/goseal",
+	}
+	ruleIndex := make(map[string]int)
+	for i, rule := range rules {
+		ruleIndex[rule.Code] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:               rule.Code,
+			Name:             rule.Option,
+			ShortDescription: sarifMessage{Text: rule.Doc},
+			HelpURI:          rule.URL(),
+		})
+	}
+
+	results := []sarifResult{}
+	for _, f := range findings {
+		results = append(results, sarifResult{
+			RuleID:    f.Rule.Code,
+			RuleIndex: ruleIndex[f.Rule.Code],
+			Level:     "error",
+			Message:   sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: f.Pos.Filename},
+					Region:           sarifRegion{StartLine: f.Pos.Line, StartColumn: f.Pos.Column},
+				},
+			}},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	})
+}
+
+// Checkstyle XML
+
+type checkstyleOutput struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func writeCheckstyle(w io.Writer, findings []finding) error {
+	out := checkstyleOutput{Version: "5.0"}
+	for _, group := range groupByFile(findings) {
+		file := checkstyleFile{Name: group[0].Pos.Filename}
+		for _, f := range group {
+			file.Errors = append(file.Errors, checkstyleError{
+				Line:     f.Pos.Line,
+				Column:   f.Pos.Column,
+				Severity: "error",
+				Message:  f.Message,
+				Source:   "goseal." + f.Rule.Code,
+			})
+		}
+		out.Files = append(out.Files, file)
+	}
+	return writeXML(w, out)
+}
+
+// JUnit XML
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+func writeJUnit(w io.Writer, findings []finding) error {
+	out := junitTestSuites{}
+	for _, group := range groupByFile(findings) {
+		suite := junitTestSuite{
+			Name:     group[0].Pos.Filename,
+			Tests:    len(group),
+			Failures: len(group),
+		}
+		for _, f := range group {
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      fmt.Sprintf("%s: %s", f.Rule.Code, f.Pos),
+				ClassName: "goseal",
+				Failure: &junitFailure{
+					Message: f.Message,
+					Type:    f.Rule.Code,
+					Content: fmt.Sprintf("%s: %s\n%s", f.Pos, f.Message, f.Rule.URL()),
+				},
+			})
+		}
+		out.TestSuites = append(out.TestSuites, suite)
+	}
+	// Report a passing test case so that CI shows that goseal ran.
+	if len(out.TestSuites) == 0 {
+		out.TestSuites = []junitTestSuite{{
+			Name:      "goseal",
+			Tests:     1,
+			TestCases: []junitTestCase{{Name: "goseal", ClassName: "goseal"}},
+		}}
+	}
+	return writeXML(w, out)
+}
+
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// GitHub Actions workflow commands
+// (https://docs.github.com/en/actions/reference/workflow-commands-for-github-actions)
+
+func writeGitHub(w io.Writer, findings []finding) error {
+	for _, f := range findings {
+		_, err := fmt.Fprintf(w, "::error file=%s,line=%d,col=%d,title=%s::%s\n",
+			escapeGitHubProperty(f.Pos.Filename),
+			f.Pos.Line,
+			f.Pos.Column,
+			escapeGitHubProperty("goseal "+f.Rule.Code),
+			escapeGitHubData(f.Message),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// Markdown summary for pull request comments
+
+func writeMarkdown(w io.Writer, findings []finding) error {
+	var b strings.Builder
+	b.WriteString("## goseal\n\n")
+
+	if len(findings) == 0 {
+		b.WriteString("No violations found.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	counts := make(map[string]int)
+	for _, f := range findings {
+		counts[f.Rule.Code]++
+	}
+	fmt.Fprintf(&b, "Found %d violation(s).\n\n", len(findings))
+	b.WriteString("| Rule | Description | Count |\n")
+	b.WriteString("|------|-------------|------:|\n")
+	for _, rule := range goseal.Rules() {
+		if counts[rule.Code] == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "| [%s](%s) `%s` | %s | %d |\n", rule.Code, rule.URL(), rule.Option, escapeMarkdownCell(rule.Doc), counts[rule.Code])
+	}
+
+	b.WriteString("\n<details>\n<summary>Details</summary>\n\n")
+	b.WriteString("| Location | Rule | Message |\n")
+	b.WriteString("|----------|------|---------|\n")
+	for _, f := range findings {
+		fmt.Fprintf(&b, "| `%s` | %s | %s |\n", f.Pos, f.Rule.Code, escapeMarkdownCell(f.Message))
+	}
+	b.WriteString("\n</details>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func escapeMarkdownCell(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
+
+// groupByFile splits findings sorted by position into per-file groups.
+func groupByFile(findings []finding) [][]finding {
+	var groups [][]finding
+	for i, f := range findings {
+		if i == 0 || f.Pos.Filename != findings[i-1].Pos.Filename {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], f)
+	}
+	return groups
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"go/token"
+	"testing"
+
+	"github.com/jimmysharp/goseal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormats(t *testing.T) {
+	findings := []finding{
+		{
+			Rule:    goseal.RuleInitScope,
+			Pos:     token.Position{Filename: "app/main.go", Line: 13, Column: 6},
+			Message: "direct construction of sealed struct User is not allowed from outside its package (init-scope: same-package)",
+		},
+		{
+			Rule:    goseal.RuleMutationScope,
+			Pos:     token.Position{Filename: "domain/user_service.go", Line: 21, Column: 2},
+			Message: "direct assignment to field Name of sealed struct User is not allowed outside its receiver methods (mutation-scope: receiver)",
+		},
+	}
+
+	tests := []struct {
+		format string
+		check  func(t *testing.T, out []byte)
+	}{
+		{
+			format: "sarif",
+			check: func(t *testing.T, out []byte) {
+				var log sarifLog
+				require.NoError(t, json.Unmarshal(out, &log))
+				require.Len(t, log.Runs, 1)
+				require.Len(t, log.Runs[0].Tool.Driver.Rules, len(goseal.Rules()))
+				require.Len(t, log.Runs[0].Results, 2)
+				result := log.Runs[0].Results[1]
+				require.Equal(t, "GS003", result.RuleID)
+				require.Equal(t, "GS003", log.Runs[0].Tool.Driver.Rules[result.RuleIndex].ID)
+				require.Equal(t, "domain/user_service.go", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+			},
+		},
+		{
+			format: "checkstyle",
+			check: func(t *testing.T, out []byte) {
+				var cs checkstyleOutput
+				require.NoError(t, xml.Unmarshal(out, &cs))
+				require.Len(t, cs.Files, 2)
+				require.Equal(t, "goseal.GS001", cs.Files[0].Errors[0].Source)
+			},
+		},
+		{
+			format: "junit",
+			check: func(t *testing.T, out []byte) {
+				var ju junitTestSuites
+				require.NoError(t, xml.Unmarshal(out, &ju))
+				require.Len(t, ju.TestSuites, 2)
+				require.Equal(t, 1, ju.TestSuites[0].Failures)
+			},
+		},
+		{
+			format: "github",
+			check: func(t *testing.T, out []byte) {
+				require.Contains(t, string(out), "::error file=app/main.go,line=13,col=6,title=goseal GS001::direct construction")
+			},
+		},
+		{
+			format: "markdown",
+			check: func(t *testing.T, out []byte) {
+				require.Contains(t, string(out), "Found 2 violation(s).")
+				require.Contains(t, string(out), "| [GS001]("+goseal.RuleInitScope.URL()+") `init-scope` |")
+				require.Contains(t, string(out), "| `app/main.go:13:6` | GS001 |")
+			},
+		},
+		{
+			format: "text",
+			check: func(t *testing.T, out []byte) {
+				require.Contains(t, string(out), "app/main.go:13:6: direct construction of sealed struct User")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, formats[tt.format](&buf, findings))
+			tt.check(t, buf.Bytes())
+		})
+	}
+}
+
+func TestHasFormatFlag(t *testing.T) {
+	require.True(t, hasFormatFlag([]string{"-format", "sarif", "./..."}))
+	require.True(t, hasFormatFlag([]string{"--format=github", "./..."}))
+	require.False(t, hasFormatFlag([]string{"-json", "./..."}))
+	require.False(t, hasFormatFlag([]string{"--", "-format"}))
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jimmysharp/goseal"
+)
+
+// runGraph implements "goseal graph" and returns the exit code.
+func runGraph(args []string) int {
+	fs := flag.NewFlagSet("graph", flag.ContinueOnError)
+	configPath := fs.String("config", ".goseal.yml", "path to the config file")
+	format := fs.String("format", "dot", "output format (dot or mermaid)")
+	tests := fs.Bool("test", true, "indicates whether test files should be analyzed, too")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "goseal graph: no packages given")
+		return 2
+	}
+
+	var write func(io.Writer, *goseal.Graph) error
+	switch *format {
+	case "dot":
+		write = writeGraphDOT
+	case "mermaid":
+		write = writeGraphMermaid
+	default:
+		fmt.Fprintf(os.Stderr, "goseal graph: unknown format %q (must be 'dot' or 'mermaid')\n", *format)
+		return 2
+	}
+
+	if err := graph(*configPath, fs.Args(), *tests, write); err != nil {
+		fmt.Fprintf(os.Stderr, "goseal graph: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func graph(configPath string, patterns []string, tests bool, write func(io.Writer, *goseal.Graph) error) error {
+	config, err := goseal.ParseConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	// The analyzer uses facts, which are computed from the syntax of dependencies
+	pkgs, err := loadPackages(patterns, tests, true)
+	if err != nil {
+		return err
+	}
+
+	g, err := goseal.BuildGraph(config, pkgs)
+	if err != nil {
+		return err
+	}
+	return write(os.Stdout, g)
+}
+
+// edgeLabel describes an edge, e.g. "construct: violating GS001 (init-scope) x2".
+func edgeLabel(e goseal.GraphEdge) string {
+	outcome := "violating"
+	if e.Allowed {
+		outcome = "allowed"
+	}
+	return fmt.Sprintf("%s: %s %s (%s) x%d", e.Action, outcome, e.Rule.Code, e.Rule.Option, e.Count)
+}
+
+func edgeColor(e goseal.GraphEdge) string {
+	if e.Allowed {
+		return "darkgreen"
+	}
+	return "red"
+}
+
+func writeGraphDOT(w io.Writer, g *goseal.Graph) error {
+	var b strings.Builder
+	b.WriteString("digraph goseal {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, p := range g.Packages {
+		fmt.Fprintf(&b, "  %s [shape=box];\n", strconv.Quote(p))
+	}
+	for _, s := range g.Structs {
+		fmt.Fprintf(&b, "  %s [shape=ellipse];\n", strconv.Quote(s))
+	}
+	for _, e := range g.Edges {
+		style := "solid"
+		if e.Allowed {
+			style = "dashed"
+		}
+		fmt.Fprintf(&b, "  %s -> %s [label=%s, color=%s, style=%s];\n",
+			strconv.Quote(e.From), strconv.Quote(e.To), strconv.Quote(edgeLabel(e)), edgeColor(e), style)
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeGraphMermaid(w io.Writer, g *goseal.Graph) error {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	ids := make(map[string]string)
+	for i, p := range g.Packages {
+		ids[p] = fmt.Sprintf("p%d", i)
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[p], mermaidEscape(p))
+	}
+	for i, s := range g.Structs {
+		ids[s] = fmt.Sprintf("s%d", i)
+		fmt.Fprintf(&b, "  %s([\"%s\"])\n", ids[s], mermaidEscape(s))
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Allowed {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s|\"%s\"| %s\n", ids[e.From], arrow, mermaidEscape(edgeLabel(e)), ids[e.To])
+	}
+	for i, e := range g.Edges {
+		fmt.Fprintf(&b, "  linkStyle %d stroke:%s\n", i, edgeColor(e))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jimmysharp/goseal"
+)
+
+// runInventory implements "goseal inventory" and returns the exit code.
+func runInventory(args []string) int {
+	fs := flag.NewFlagSet("inventory", flag.ContinueOnError)
+	configPath := fs.String("config", ".goseal.yml", "path to the config file")
+	format := fs.String("format", "json", "output format (json or markdown)")
+	tests := fs.Bool("test", true, "indicates whether test files should be analyzed, too")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "goseal inventory: no packages given")
+		return 2
+	}
+
+	var write func(io.Writer, *goseal.Inventory) error
+	switch *format {
+	case "json":
+		write = writeInventoryJSON
+	case "markdown":
+		write = writeInventoryMarkdown
+	default:
+		fmt.Fprintf(os.Stderr, "goseal inventory: unknown format %q (must be 'json' or 'markdown')\n", *format)
+		return 2
+	}
+
+	if err := inventory(*configPath, fs.Args(), *tests, write); err != nil {
+		fmt.Fprintf(os.Stderr, "goseal inventory: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func inventory(configPath string, patterns []string, tests bool, write func(io.Writer, *goseal.Inventory) error) error {
+	config, err := goseal.ParseConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	// The analyzer uses facts, which are computed from the syntax of dependencies
+	pkgs, err := loadPackages(patterns, tests, true)
+	if err != nil {
+		return err
+	}
+
+	inv, err := goseal.BuildInventory(config, pkgs)
+	if err != nil {
+		return err
+	}
+	return write(os.Stdout, inv)
+}
+
+func writeInventoryJSON(w io.Writer, inv *goseal.Inventory) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(inv)
+}
+
+func writeInventoryMarkdown(w io.Writer, inv *goseal.Inventory) error {
+	var b strings.Builder
+
+	b.WriteString("## Sealed structs\n\n")
+	if len(inv.Structs) == 0 {
+		b.WriteString("No sealed structs found.\n")
+	} else {
+		b.WriteString("| Struct | init-scope | mutation-scope | Factories | Mutators | Violations |\n")
+		b.WriteString("|--------|------------|----------------|-----------|----------|-----------:|\n")
+		for _, s := range inv.Structs {
+			total := 0
+			for _, v := range s.Violations {
+				total += v.Count
+			}
+			var testInitScope, testMutationScope string
+			if s.Tests != nil {
+				testInitScope, testMutationScope = string(s.Tests.InitScope), string(s.Tests.MutationScope)
+			}
+			fmt.Fprintf(&b, "| `%s.%s` | %s | %s | %s | %s | %d |\n",
+				s.Package, s.Name,
+				scopeCell(string(s.InitScope), testInitScope, s.InitAllowedFrom),
+				scopeCell(string(s.MutationScope), testMutationScope, s.MutationAllowedFrom),
+				codeList(s.Factories), codeList(s.Mutators), total)
+		}
+	}
+
+	b.WriteString("\n## Violations by package\n\n")
+	if len(inv.Packages) == 0 {
+		b.WriteString("No violations found.\n")
+	} else {
+		// Only show the rules that have violations
+		var rules []goseal.Rule
+		for _, rule := range goseal.Rules() {
+			for _, p := range inv.Packages {
+				if p.Violations[rule.Code] > 0 {
+					rules = append(rules, rule)
+					break
+				}
+			}
+		}
+		b.WriteString("| Package |")
+		for _, rule := range rules {
+			fmt.Fprintf(&b, " %s |", rule.Code)
+		}
+		b.WriteString(" Total |\n|---------|")
+		b.WriteString(strings.Repeat("------:|", len(rules)+1))
+		b.WriteString("\n")
+		for _, p := range inv.Packages {
+			fmt.Fprintf(&b, "| `%s` |", p.Package)
+			for _, rule := range rules {
+				fmt.Fprintf(&b, " %d |", p.Violations[rule.Code])
+			}
+			fmt.Fprintf(&b, " %d |\n", p.Total)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// scopeCell formats a scope with the scope in test code, if different, and
+// the packages allowed regardless of it.
+func scopeCell(scope, testScope string, allowedFrom []string) string {
+	cell := scope
+	if testScope != "" && testScope != scope {
+		cell += " (tests: " + testScope + ")"
+	}
+	if len(allowedFrom) > 0 {
+		cell += ", allowed from " + codeList(allowedFrom)
+	}
+	return cell
+}
+
+// codeList formats names as a comma-separated list of code spans.
+func codeList(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "`" + name + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jimmysharp/goseal"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// finding is a diagnostic prepared for output.
+type finding struct {
+	Rule    goseal.Rule
+	Pos     token.Position // Filename is relative to the working directory when possible
+	Message string
+}
+
+// hasFormatFlag reports whether args select an output format, in which case
+// goseal runs its own driver instead of multichecker.
+func hasFormatFlag(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if strings.HasPrefix(arg, "-") && name == "format" {
+			return true
+		}
+	}
+	return false
+}
+
+// runLint analyzes the packages in args and writes the findings in the
+// selected format. The exit code follows multichecker: 1 for errors and
+// 3 when diagnostics were reported.
+func runLint(a *analysis.Analyzer, args []string) int {
+	fs := flag.NewFlagSet("goseal", flag.ContinueOnError)
+	format := fs.String("format", "", "output format: "+strings.Join(formatNames(), ", "))
+	tests := fs.Bool("test", true, "indicates whether test files should be analyzed, too")
+	a.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	write, ok := formats[*format]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "goseal: unknown format %q (must be one of %s)\n", *format, strings.Join(formatNames(), ", "))
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "goseal: no packages given")
+		return 2
+	}
+
+	findings, err := analyze(a, fs.Args(), *tests)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goseal: %v\n", err)
+		return 1
+	}
+
+	if err := write(os.Stdout, findings); err != nil {
+		fmt.Fprintf(os.Stderr, "goseal: %v\n", err)
+		return 1
+	}
+	if len(findings) > 0 {
+		return 3
+	}
+	return 0
+}
+
+// analyze loads the packages matching patterns and returns the findings of a,
+// sorted by position and without the duplicates reported for test variants.
+func analyze(a *analysis.Analyzer, patterns []string, tests bool) ([]finding, error) {
+	pkgs, err := loadPackages(patterns, tests, usesFacts(a))
+	if err != nil {
+		return nil, err
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{a}, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cwd, _ := os.Getwd()
+	seen := make(map[string]bool)
+	var findings []finding
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			return nil, fmt.Errorf("%s: %w", act.Package.PkgPath, act.Err)
+		}
+		for _, d := range act.Diagnostics {
+			pos := relativePosition(cwd, act.Package.Fset.Position(d.Pos))
+
+			key := pos.String() + "\x00" + d.Message
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			rule, _ := goseal.LookupRule(d.Category)
+			findings = append(findings, finding{Rule: rule, Pos: pos, Message: d.Message})
+		}
+	}
+
+	slices.SortFunc(findings, func(x, y finding) int {
+		if c := strings.Compare(x.Pos.Filename, y.Pos.Filename); c != 0 {
+			return c
+		}
+		if x.Pos.Line != y.Pos.Line {
+			return x.Pos.Line - y.Pos.Line
+		}
+		return x.Pos.Column - y.Pos.Column
+	})
+	return findings, nil
+}
+
+// usesFacts reports whether a or an analyzer it requires uses facts, in
+// which case dependencies must be analyzed from their syntax too.
+func usesFacts(a *analysis.Analyzer) bool {
+	return len(a.FactTypes) > 0 || slices.ContainsFunc(a.Requires, usesFacts)
+}
+
+// relativePosition makes the filename of pos relative to cwd when it is inside cwd.
+func relativePosition(cwd string, pos token.Position) token.Position {
+	if rel, err := filepath.Rel(cwd, pos.Filename); err == nil && !strings.HasPrefix(rel, "..") {
+		pos.Filename = rel
+	}
+	pos.Filename = filepath.ToSlash(pos.Filename)
+	return pos
+}
+
+// loadPackages loads packages the same way multichecker does.
+func loadPackages(patterns []string, tests, allSyntax bool) ([]*packages.Package, error) {
+	mode := packages.LoadSyntax
+	if allSyntax {
+		mode = packages.LoadAllSyntax
+	}
+	mode |= packages.NeedModule
+
+	pkgs, err := packages.Load(&packages.Config{Mode: mode, Tests: tests}, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("%s matched no packages", strings.Join(patterns, " "))
+	}
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		return nil, fmt.Errorf("failed to load packages (%d errors)", n)
+	}
+	return pkgs, nil
+}
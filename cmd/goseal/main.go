@@ -1,19 +1,44 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"os"
 
 	"github.com/jimmysharp/goseal"
-	"golang.org/x/tools/go/analysis/singlechecker"
+	"golang.org/x/tools/go/analysis/multichecker"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "config":
+			os.Exit(runConfig(os.Args[2:]))
+		case "inventory":
+			os.Exit(runInventory(os.Args[2:]))
+		case "graph":
+			os.Exit(runGraph(os.Args[2:]))
+		case "advise":
+			os.Exit(runAdvise(os.Args[2:]))
+		}
+	}
+
 	config, err := goseal.ParseConfig(".goseal.yml")
 	if err != nil {
+		fmt.Fprintf(os.Stderr, "goseal: %v\n", err)
 		os.Exit(1)
 	}
 
-	a := goseal.NewAnalyzer(config)
+	if hasFormatFlag(os.Args[1:]) {
+		os.Exit(runLint(goseal.NewAnalyzer(config), os.Args[1:]))
+	}
+
+	// The last analyzer owns the baseline flags; keep accepting them without
+	// the goseal. prefix that multichecker adds
+	analyzers := goseal.NewAnalyzers(config)
+	analyzers[len(analyzers)-1].Flags.VisitAll(func(f *flag.Flag) {
+		flag.Var(f.Value, f.Name, f.Usage)
+	})
 
-	singlechecker.Main(a)
+	multichecker.Main(analyzers...)
 }
@@ -1,10 +1,13 @@
 package goseal
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/goccy/go-yaml"
 )
@@ -27,105 +30,545 @@ const (
 	MutationScopeNever            MutationScope = "never"
 )
 
+type CopyScope string
+
+const (
+	CopyScopeAny              CopyScope = "any"
+	CopyScopeInTargetPackages CopyScope = "in-target-packages"
+	CopyScopeSamePackage      CopyScope = "same-package"
+)
+
+// ClosurePolicy controls whether function literals inherit the factory and
+// receiver status of the function declaring them.
+type ClosurePolicy string
+
+const (
+	ClosuresInherit         ClosurePolicy = "inherit"
+	ClosuresDeny            ClosurePolicy = "deny"
+	ClosuresSamePackageOnly ClosurePolicy = "same-package-only"
+)
+
 type Config struct {
 	TargetPackages []*regexp.Regexp // Regex patterns for packages containing target structs (if empty, all packages are targeted)
 	ExcludeStructs []*regexp.Regexp // Regex patterns for struct names to exclude from protection
 	FactoryNames   []*regexp.Regexp // Regex patterns for factory function names (if empty, all function names are allowed)
 	InitScope      InitScope        // Scope for struct initialization
 	MutationScope  MutationScope    // Scope for field mutation
-	IgnoreFiles    []*regexp.Regexp // Regex patterns for files to ignore
+	CopyScope      CopyScope        // Scope for copying struct values
+	Closures       ClosurePolicy    // Whether function literals inherit factory and receiver status
+	IgnoreFiles    []*IgnoreFile    // Files to ignore, entirely or for some rules
+	DisabledRules  []string         // Diagnostic codes (e.g. "GS002") that are not reported
+	Tests          *TestsConfig     // Scopes for test code (if nil, test code is checked like other code)
+
+	InitAllowedFrom     []*regexp.Regexp // Regex patterns for packages allowed to construct any sealed struct
+	MutationAllowedFrom []*regexp.Regexp // Regex patterns for packages allowed to mutate any sealed struct
+	Structs             []*StructConfig  // Per-struct settings
+
+	RestrictedFunctions []*RestrictedFunction // Functions that may only be called from certain packages
+
+	CheckAliasing bool // Report getters and factories that share slices, maps and pointers with callers
+
+	ReadOnlyMethods []*regexp.Regexp // Regex patterns for qualified names of pointer-receiver methods that do not write their receiver
+
+	RequireAllFields bool // Require composite literals in factory functions to set every field
+
+	RequireValidation bool             // Require factory functions to validate the sealed structs they construct
+	ValidateMethods   []*regexp.Regexp // Regex patterns for names of validation methods
+
+	RequireInvariantCheck bool // Require methods writing fields of sealed structs to call a validation method afterwards
+
+	AllowPackageVars   bool // Allow construction in package-level variable declarations of the package declaring the struct
+	AllowInitFuncs     bool // Allow construction in init functions of the package declaring the struct
+	ReportExportedVars bool // Report exported package-level variables holding sealed structs
+
+	Generated GeneratedConfig // How generated files are checked
 }
 
-func (c *Config) UnmarshalJSON(data []byte) error {
-	type rawConfig struct {
-		TargetPackages []string `json:"target-packages"`
-		ExcludeStructs []string `json:"exclude-structs"`
-		FactoryNames   []string `json:"factory-names"`
-		InitScope      string   `json:"init-scope"`
-		MutationScope  string   `json:"mutation-scope"`
-		IgnoreFiles    []string `json:"ignore-files"`
+// StructConfig configures the sealed structs matching Pattern.
+type StructConfig struct {
+	Pattern             *regexp.Regexp   // Regex pattern for qualified struct names (e.g. example.com/domain.User)
+	InitAllowedFrom     []*regexp.Regexp // Regex patterns for packages allowed to construct the structs
+	MutationAllowedFrom []*regexp.Regexp // Regex patterns for packages allowed to mutate the structs
+	RequireAllFields    bool             // Require composite literals in factory functions to set every field
+}
+
+// TestsConfig configures how code in _test.go files and test fixture packages is checked.
+type TestsConfig struct {
+	InitScope           InitScope        // Scope for struct initialization in test code (default: init-scope)
+	MutationScope       MutationScope    // Scope for field mutation in test code (default: mutation-scope)
+	CopyScope           CopyScope        // Scope for copying struct values in test code (default: copy-scope)
+	ExternalTestPackage bool             // Treat external test packages (e.g. domain_test) as the package under test
+	FixturePackages     []*regexp.Regexp // Regex patterns for packages treated as test code of every target package
+	EnforceFactoryNames bool             // Apply factory-names to test code
+}
+
+// GeneratedConfig configures how files with a "Code generated ... DO NOT EDIT." header are checked.
+type GeneratedConfig struct {
+	Check      bool               // Check generated files instead of skipping them
+	Generators []*GeneratorPolicy // Policies for files of specific generators; the first matching one applies
+}
+
+// GeneratorPolicy configures how files generated by the tools matching Pattern are checked.
+type GeneratorPolicy struct {
+	Pattern       *regexp.Regexp // Regex pattern for the generator name in the header (e.g. sqlc for "Code generated by sqlc. DO NOT EDIT.")
+	Check         bool           // Check the generated files instead of skipping them
+	DisabledRules []string       // Diagnostic codes that are not reported in the generated files
+}
+
+// IgnoreFile ignores the files whose module-relative, slash-separated path
+// (e.g. internal/mocks/user.go) matches Pattern or Glob.
+type IgnoreFile struct {
+	Pattern *regexp.Regexp // Regex pattern for the path
+	Glob    string         // Glob for the path, where ** matches any number of directories (e.g. **/mocks/*.go)
+	Rules   []string       // Diagnostic codes not reported in the files (if empty, the files are not checked at all)
+}
+
+// RestrictedFunction restricts the callers of the functions and methods matching Pattern.
+type RestrictedFunction struct {
+	Pattern     *regexp.Regexp   // Regex pattern for qualified function names (e.g. example.com/domain.ReconstituteUser or example.com/domain.User.Restore)
+	Scope       InitScope        // Scope of the allowed callers
+	AllowedFrom []*regexp.Regexp // Regex patterns for packages allowed to call the functions regardless of Scope
+}
+
+func (c *Config) isRuleDisabled(rule Rule) bool {
+	return slices.Contains(c.DisabledRules, rule.Code)
+}
+
+// usesReceiverWrites reports whether a check needs to know which methods
+// write their receiver: calls of mutating methods (GS003) and invariant
+// checks (GS010).
+func (c *Config) usesReceiverWrites() bool {
+	return !c.isRuleDisabled(RuleMutationScope) || (c.RequireInvariantCheck && !c.isRuleDisabled(RuleInvariantCheck))
+}
+
+// structConfigs returns the per-struct settings matching the qualified struct name.
+func (c *Config) structConfigs(qualifiedName string) []*StructConfig {
+	var matched []*StructConfig
+	for _, s := range c.Structs {
+		if s.Pattern.MatchString(qualifiedName) {
+			matched = append(matched, s)
+		}
 	}
+	return matched
+}
+
+// rawConfig is the serialized form of Config, shared by the YAML file,
+// the golangci-lint plugin settings and the JSON Schema.
+type rawConfig struct {
+	TargetPackages []string        `json:"target-packages"`
+	ExcludeStructs []string        `json:"exclude-structs"`
+	FactoryNames   []string        `json:"factory-names"`
+	InitScope      string          `json:"init-scope"`
+	MutationScope  string          `json:"mutation-scope"`
+	CopyScope      string          `json:"copy-scope"`
+	Closures       string          `json:"closures"`
+	IgnoreFiles    []rawIgnoreFile `json:"ignore-files"`
+	DisabledRules  []string        `json:"disabled-rules"`
+
+	Tests *rawTestsConfig `json:"tests,omitempty"`
+
+	InitAllowedFrom     []string          `json:"init-allowed-from"`
+	MutationAllowedFrom []string          `json:"mutation-allowed-from"`
+	Structs             []rawStructConfig `json:"structs"`
+
+	RestrictedFunctions []rawRestrictedFunction `json:"restricted-functions"`
+
+	CheckAliasing bool `json:"check-aliasing"`
+
+	ReadOnlyMethods []string `json:"read-only-methods"`
+
+	RequireAllFields bool `json:"require-all-fields"`
+
+	RequireValidation bool     `json:"require-validation"`
+	ValidateMethods   []string `json:"validate-methods"`
+
+	RequireInvariantCheck bool `json:"require-invariant-check"`
+
+	AllowPackageVars   bool `json:"allow-package-vars"`
+	AllowInitFuncs     bool `json:"allow-init-funcs"`
+	ReportExportedVars bool `json:"report-exported-vars"`
+
+	Generated rawGeneratedConfig `json:"generated"`
+}
+
+type rawGeneratedConfig struct {
+	Check      bool                 `json:"check"`
+	Generators []rawGeneratorPolicy `json:"generators"`
+}
+
+type rawGeneratorPolicy struct {
+	Pattern       string   `json:"pattern"`
+	Check         *bool    `json:"check,omitempty"`
+	DisabledRules []string `json:"disabled-rules"`
+}
+
+// rawIgnoreFile is an entry of ignore-files: either a regexp or an object.
+type rawIgnoreFile struct {
+	Pattern string   `json:"pattern,omitempty"`
+	Glob    string   `json:"glob,omitempty"`
+	Rules   []string `json:"rules,omitempty"`
+}
+
+func (r *rawIgnoreFile) UnmarshalJSON(data []byte) error {
+	var pattern string
+	if err := json.Unmarshal(data, &pattern); err == nil {
+		*r = rawIgnoreFile{Pattern: pattern}
+		return nil
+	}
+	type plain rawIgnoreFile
+	return decodeStrict(data, (*plain)(r))
+}
+
+func (r rawIgnoreFile) MarshalJSON() ([]byte, error) {
+	if r.Glob == "" && len(r.Rules) == 0 {
+		return json.Marshal(r.Pattern)
+	}
+	type plain rawIgnoreFile
+	return json.Marshal(plain(r))
+}
+
+type rawTestsConfig struct {
+	InitScope           string   `json:"init-scope"`
+	MutationScope       string   `json:"mutation-scope"`
+	CopyScope           string   `json:"copy-scope"`
+	ExternalTestPackage bool     `json:"external-test-package"`
+	FixturePackages     []string `json:"fixture-packages"`
+	EnforceFactoryNames bool     `json:"enforce-factory-names"`
+}
+
+type rawRestrictedFunction struct {
+	Pattern     string   `json:"pattern"`
+	Scope       string   `json:"scope"`
+	AllowedFrom []string `json:"allowed-from"`
+}
+
+type rawStructConfig struct {
+	Pattern             string   `json:"pattern"`
+	InitAllowedFrom     []string `json:"init-allowed-from"`
+	MutationAllowedFrom []string `json:"mutation-allowed-from"`
+	RequireAllFields    bool     `json:"require-all-fields"`
+}
 
+func (c *Config) UnmarshalJSON(data []byte) error {
 	var raw rawConfig
-	if err := json.Unmarshal(data, &raw); err != nil {
+	if err := decodeStrict(data, &raw); err != nil {
+		return err
+	}
+
+	targetPackages, err := compilePatterns("target-packages", raw.TargetPackages)
+	if err != nil {
+		return err
+	}
+	excludeStructs, err := compilePatterns("exclude-structs", raw.ExcludeStructs)
+	if err != nil {
+		return err
+	}
+	factoryNames, err := compilePatterns("factory-names", raw.FactoryNames)
+	if err != nil {
+		return err
+	}
+	ignoreFiles, err := compileIgnoreFiles(raw.IgnoreFiles)
+	if err != nil {
+		return err
+	}
+	initAllowedFrom, err := compilePatterns("init-allowed-from", raw.InitAllowedFrom)
+	if err != nil {
+		return err
+	}
+	mutationAllowedFrom, err := compilePatterns("mutation-allowed-from", raw.MutationAllowedFrom)
+	if err != nil {
+		return err
+	}
+	structs, err := compileStructConfigs(raw.Structs)
+	if err != nil {
+		return err
+	}
+	restrictedFunctions, err := compileRestrictedFunctions(raw.RestrictedFunctions)
+	if err != nil {
+		return err
+	}
+	readOnlyMethods, err := compilePatterns("read-only-methods", raw.ReadOnlyMethods)
+	if err != nil {
+		return err
+	}
+	validateMethods, err := compilePatterns("validate-methods", raw.ValidateMethods)
+	if err != nil {
+		return err
+	}
+	generators, err := compileGeneratorPolicies(raw.Generated)
+	if err != nil {
 		return err
 	}
 
-	var targetPackages []*regexp.Regexp
-	var excludeStructs []*regexp.Regexp
-	var factoryNames []*regexp.Regexp
-	var ignoreFiles []*regexp.Regexp
+	cfg := Config{
+		TargetPackages: targetPackages,
+		ExcludeStructs: excludeStructs,
+		FactoryNames:   factoryNames,
+		InitScope:      InitScope(raw.InitScope),
+		MutationScope:  MutationScope(raw.MutationScope),
+		CopyScope:      CopyScope(raw.CopyScope),
+		Closures:       ClosurePolicy(raw.Closures),
+		IgnoreFiles:    ignoreFiles,
+		DisabledRules:  raw.DisabledRules,
 
-	// target-packages
-	if raw.TargetPackages != nil {
-		targetPackages = make([]*regexp.Regexp, len(raw.TargetPackages))
-		for i, pattern := range raw.TargetPackages {
-			re, err := regexp.Compile(pattern)
-			if err != nil {
-				return fmt.Errorf("invalid target-packages pattern '%s': %w", pattern, err)
-			}
-			targetPackages[i] = re
+		InitAllowedFrom:     initAllowedFrom,
+		MutationAllowedFrom: mutationAllowedFrom,
+		Structs:             structs,
+
+		RestrictedFunctions: restrictedFunctions,
+
+		CheckAliasing: raw.CheckAliasing,
+
+		ReadOnlyMethods: readOnlyMethods,
+
+		RequireAllFields: raw.RequireAllFields,
+
+		RequireValidation: raw.RequireValidation,
+		ValidateMethods:   validateMethods,
+
+		RequireInvariantCheck: raw.RequireInvariantCheck,
+
+		AllowPackageVars:   raw.AllowPackageVars,
+		AllowInitFuncs:     raw.AllowInitFuncs,
+		ReportExportedVars: raw.ReportExportedVars,
+
+		Generated: GeneratedConfig{
+			Check:      raw.Generated.Check,
+			Generators: generators,
+		},
+	}
+	if raw.Tests != nil {
+		fixturePackages, err := compilePatterns("tests.fixture-packages", raw.Tests.FixturePackages)
+		if err != nil {
+			return err
 		}
-	} else {
-		targetPackages = []*regexp.Regexp{}
+		cfg.Tests = &TestsConfig{
+			InitScope:           InitScope(raw.Tests.InitScope),
+			MutationScope:       MutationScope(raw.Tests.MutationScope),
+			CopyScope:           CopyScope(raw.Tests.CopyScope),
+			ExternalTestPackage: raw.Tests.ExternalTestPackage,
+			FixturePackages:     fixturePackages,
+			EnforceFactoryNames: raw.Tests.EnforceFactoryNames,
+		}
+	}
+	if err := cfg.normalize(); err != nil {
+		return err
 	}
 
-	// exclude-structs
-	if raw.ExcludeStructs != nil {
-		excludeStructs = make([]*regexp.Regexp, len(raw.ExcludeStructs))
-		for i, pattern := range raw.ExcludeStructs {
-			re, err := regexp.Compile(pattern)
-			if err != nil {
-				return fmt.Errorf("invalid exclude-structs pattern '%s': %w", pattern, err)
-			}
-			excludeStructs[i] = re
+	*c = cfg
+	return nil
+}
+
+func (c *Config) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.raw())
+}
+
+func (c *Config) raw() rawConfig {
+	raw := rawConfig{
+		TargetPackages: patternStrings(c.TargetPackages),
+		ExcludeStructs: patternStrings(c.ExcludeStructs),
+		FactoryNames:   patternStrings(c.FactoryNames),
+		InitScope:      string(c.InitScope),
+		MutationScope:  string(c.MutationScope),
+		CopyScope:      string(c.CopyScope),
+		Closures:       string(c.Closures),
+		IgnoreFiles:    []rawIgnoreFile{},
+		DisabledRules:  c.DisabledRules,
+
+		InitAllowedFrom:     patternStrings(c.InitAllowedFrom),
+		MutationAllowedFrom: patternStrings(c.MutationAllowedFrom),
+		Structs:             []rawStructConfig{},
+
+		RestrictedFunctions: []rawRestrictedFunction{},
+
+		CheckAliasing: c.CheckAliasing,
+
+		ReadOnlyMethods: patternStrings(c.ReadOnlyMethods),
+
+		RequireAllFields: c.RequireAllFields,
+
+		RequireValidation: c.RequireValidation,
+		ValidateMethods:   patternStrings(c.ValidateMethods),
+
+		RequireInvariantCheck: c.RequireInvariantCheck,
+
+		AllowPackageVars:   c.AllowPackageVars,
+		AllowInitFuncs:     c.AllowInitFuncs,
+		ReportExportedVars: c.ReportExportedVars,
+
+		Generated: rawGeneratedConfig{
+			Check:      c.Generated.Check,
+			Generators: []rawGeneratorPolicy{},
+		},
+	}
+	for _, sc := range c.Structs {
+		raw.Structs = append(raw.Structs, rawStructConfig{
+			Pattern:             sc.Pattern.String(),
+			InitAllowedFrom:     patternStrings(sc.InitAllowedFrom),
+			MutationAllowedFrom: patternStrings(sc.MutationAllowedFrom),
+			RequireAllFields:    sc.RequireAllFields,
+		})
+	}
+	for _, f := range c.IgnoreFiles {
+		rf := rawIgnoreFile{Glob: f.Glob, Rules: f.Rules}
+		if f.Pattern != nil {
+			rf.Pattern = f.Pattern.String()
+		}
+		raw.IgnoreFiles = append(raw.IgnoreFiles, rf)
+	}
+	for _, g := range c.Generated.Generators {
+		raw.Generated.Generators = append(raw.Generated.Generators, rawGeneratorPolicy{
+			Pattern:       g.Pattern.String(),
+			Check:         &g.Check,
+			DisabledRules: g.DisabledRules,
+		})
+	}
+	for _, rf := range c.RestrictedFunctions {
+		raw.RestrictedFunctions = append(raw.RestrictedFunctions, rawRestrictedFunction{
+			Pattern:     rf.Pattern.String(),
+			Scope:       string(rf.Scope),
+			AllowedFrom: patternStrings(rf.AllowedFrom),
+		})
+	}
+	if c.Tests != nil {
+		raw.Tests = &rawTestsConfig{
+			InitScope:           string(c.Tests.InitScope),
+			MutationScope:       string(c.Tests.MutationScope),
+			CopyScope:           string(c.Tests.CopyScope),
+			ExternalTestPackage: c.Tests.ExternalTestPackage,
+			FixturePackages:     patternStrings(c.Tests.FixturePackages),
+			EnforceFactoryNames: c.Tests.EnforceFactoryNames,
 		}
-	} else {
-		excludeStructs = []*regexp.Regexp{}
 	}
+	return raw
+}
 
-	// factory-names
-	if raw.FactoryNames != nil {
-		factoryNames = make([]*regexp.Regexp, len(raw.FactoryNames))
-		for i, pattern := range raw.FactoryNames {
-			re, err := regexp.Compile(pattern)
-			if err != nil {
-				return fmt.Errorf("invalid factory-names pattern '%s': %w", pattern, err)
-			}
-			factoryNames[i] = re
+// decodeStrict decodes data into v, rejecting keys that v does not declare
+// so that typos in the configuration are not silently ignored.
+func decodeStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		if key, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return fmt.Errorf("unknown config key %s", key)
+		}
+		return err
+	}
+	return nil
+}
+
+func compilePatterns(key string, patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, len(patterns))
+	for i, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s pattern '%s': %w", key, pattern, err)
+		}
+		compiled[i] = re
+	}
+	return compiled, nil
+}
+
+func compileStructConfigs(raw []rawStructConfig) ([]*StructConfig, error) {
+	structs := make([]*StructConfig, len(raw))
+	for i, rs := range raw {
+		key := fmt.Sprintf("structs[%d]", i)
+		if rs.Pattern == "" {
+			return nil, fmt.Errorf("invalid %s: pattern is required", key)
+		}
+		pattern, err := compilePatterns(key+".pattern", []string{rs.Pattern})
+		if err != nil {
+			return nil, err
+		}
+		initAllowedFrom, err := compilePatterns(key+".init-allowed-from", rs.InitAllowedFrom)
+		if err != nil {
+			return nil, err
+		}
+		mutationAllowedFrom, err := compilePatterns(key+".mutation-allowed-from", rs.MutationAllowedFrom)
+		if err != nil {
+			return nil, err
+		}
+		structs[i] = &StructConfig{
+			Pattern:             pattern[0],
+			InitAllowedFrom:     initAllowedFrom,
+			MutationAllowedFrom: mutationAllowedFrom,
+			RequireAllFields:    rs.RequireAllFields,
+		}
+	}
+	return structs, nil
+}
+
+func compileRestrictedFunctions(raw []rawRestrictedFunction) ([]*RestrictedFunction, error) {
+	functions := make([]*RestrictedFunction, len(raw))
+	for i, rf := range raw {
+		key := fmt.Sprintf("restricted-functions[%d]", i)
+		if rf.Pattern == "" {
+			return nil, fmt.Errorf("invalid %s: pattern is required", key)
+		}
+		pattern, err := compilePatterns(key+".pattern", []string{rf.Pattern})
+		if err != nil {
+			return nil, err
+		}
+		allowedFrom, err := compilePatterns(key+".allowed-from", rf.AllowedFrom)
+		if err != nil {
+			return nil, err
+		}
+		functions[i] = &RestrictedFunction{
+			Pattern:     pattern[0],
+			Scope:       InitScope(rf.Scope),
+			AllowedFrom: allowedFrom,
 		}
-	} else {
-		factoryNames = []*regexp.Regexp{}
 	}
+	return functions, nil
+}
 
-	// ignore-files
-	if raw.IgnoreFiles != nil {
-		ignoreFiles = make([]*regexp.Regexp, len(raw.IgnoreFiles))
-		for i, pattern := range raw.IgnoreFiles {
-			re, err := regexp.Compile(pattern)
+func compileIgnoreFiles(raw []rawIgnoreFile) ([]*IgnoreFile, error) {
+	files := make([]*IgnoreFile, len(raw))
+	for i, rf := range raw {
+		key := fmt.Sprintf("ignore-files[%d]", i)
+		files[i] = &IgnoreFile{Glob: rf.Glob, Rules: rf.Rules}
+		if rf.Pattern != "" {
+			pattern, err := compilePatterns(key+".pattern", []string{rf.Pattern})
 			if err != nil {
-				return fmt.Errorf("invalid ignore-files pattern '%s': %w", pattern, err)
+				return nil, err
 			}
-			ignoreFiles[i] = re
+			files[i].Pattern = pattern[0]
 		}
-	} else {
-		ignoreFiles = []*regexp.Regexp{}
 	}
+	return files, nil
+}
 
-	cfg, err := NewConfig(
-		targetPackages,
-		excludeStructs,
-		factoryNames,
-		InitScope(raw.InitScope),
-		MutationScope(raw.MutationScope),
-		ignoreFiles,
-	)
-	if err != nil {
-		return err
+// compileGeneratorPolicies compiles the generators of raw; policies without
+// check inherit it from raw.
+func compileGeneratorPolicies(raw rawGeneratedConfig) ([]*GeneratorPolicy, error) {
+	generators := make([]*GeneratorPolicy, len(raw.Generators))
+	for i, rg := range raw.Generators {
+		key := fmt.Sprintf("generated.generators[%d]", i)
+		if rg.Pattern == "" {
+			return nil, fmt.Errorf("invalid %s: pattern is required", key)
+		}
+		pattern, err := compilePatterns(key+".pattern", []string{rg.Pattern})
+		if err != nil {
+			return nil, err
+		}
+		generators[i] = &GeneratorPolicy{
+			Pattern:       pattern[0],
+			Check:         raw.Check,
+			DisabledRules: rg.DisabledRules,
+		}
+		if rg.Check != nil {
+			generators[i].Check = *rg.Check
+		}
 	}
+	return generators, nil
+}
 
-	*c = *cfg
-	return nil
+func patternStrings(patterns []*regexp.Regexp) []string {
+	s := make([]string, len(patterns))
+	for i, re := range patterns {
+		s[i] = re.String()
+	}
+	return s
 }
 
 func NewConfig(
@@ -136,59 +579,236 @@ func NewConfig(
 	mutationScope MutationScope,
 	ignoreFiles []*regexp.Regexp,
 ) (*Config, error) {
+	cfg := &Config{
+		TargetPackages: targetPackages,
+		ExcludeStructs: excludeStructs,
+		FactoryNames:   factoryNames,
+		InitScope:      initScope,
+		MutationScope:  mutationScope,
+	}
+	for _, pattern := range ignoreFiles {
+		cfg.IgnoreFiles = append(cfg.IgnoreFiles, &IgnoreFile{Pattern: pattern})
+	}
+	if err := cfg.normalize(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// normalize fills in default values for unset options and validates the result.
+func (c *Config) normalize() error {
 	// Set default values
-	if targetPackages == nil {
-		targetPackages = []*regexp.Regexp{}
+	if c.TargetPackages == nil {
+		c.TargetPackages = []*regexp.Regexp{}
+	}
+	if c.ExcludeStructs == nil {
+		c.ExcludeStructs = []*regexp.Regexp{}
+	}
+	if c.FactoryNames == nil {
+		c.FactoryNames = []*regexp.Regexp{}
+	}
+	if c.InitScope == "" {
+		c.InitScope = InitScopeSamePackage
+	}
+	if c.MutationScope == "" {
+		c.MutationScope = MutationScopeReceiver
+	}
+	if c.CopyScope == "" {
+		c.CopyScope = CopyScopeAny
+	}
+	if c.Closures == "" {
+		c.Closures = ClosuresInherit
+	}
+	if c.IgnoreFiles == nil {
+		c.IgnoreFiles = []*IgnoreFile{}
+	}
+	for i, f := range c.IgnoreFiles {
+		key := fmt.Sprintf("ignore-files[%d]", i)
+		switch {
+		case f.Pattern == nil && f.Glob == "":
+			return fmt.Errorf("invalid %s: pattern or glob is required", key)
+		case f.Pattern != nil && f.Glob != "":
+			return fmt.Errorf("invalid %s: set either pattern or glob, not both", key)
+		}
+		if err := validateGlob(f.Glob); err != nil {
+			return fmt.Errorf("invalid %s.glob '%s': %w", key, f.Glob, err)
+		}
+		if f.Rules == nil {
+			f.Rules = []string{}
+		}
 	}
-	if excludeStructs == nil {
-		excludeStructs = []*regexp.Regexp{}
+	if c.DisabledRules == nil {
+		c.DisabledRules = []string{}
 	}
-	if factoryNames == nil {
-		factoryNames = []*regexp.Regexp{}
+	if c.InitAllowedFrom == nil {
+		c.InitAllowedFrom = []*regexp.Regexp{}
 	}
-	if initScope == "" {
-		initScope = InitScopeSamePackage
+	if c.MutationAllowedFrom == nil {
+		c.MutationAllowedFrom = []*regexp.Regexp{}
 	}
-	if mutationScope == "" {
-		mutationScope = MutationScopeReceiver
+	if c.Structs == nil {
+		c.Structs = []*StructConfig{}
 	}
-	if ignoreFiles == nil {
-		ignoreFiles = []*regexp.Regexp{}
+	for i, sc := range c.Structs {
+		if sc.Pattern == nil {
+			return fmt.Errorf("invalid structs[%d]: pattern is required", i)
+		}
+		if sc.InitAllowedFrom == nil {
+			sc.InitAllowedFrom = []*regexp.Regexp{}
+		}
+		if sc.MutationAllowedFrom == nil {
+			sc.MutationAllowedFrom = []*regexp.Regexp{}
+		}
+	}
+	if c.ValidateMethods == nil {
+		c.ValidateMethods = []*regexp.Regexp{}
+	}
+	if c.ReadOnlyMethods == nil {
+		c.ReadOnlyMethods = []*regexp.Regexp{}
+	}
+	if c.RestrictedFunctions == nil {
+		c.RestrictedFunctions = []*RestrictedFunction{}
+	}
+	for i, rf := range c.RestrictedFunctions {
+		if rf.Pattern == nil {
+			return fmt.Errorf("invalid restricted-functions[%d]: pattern is required", i)
+		}
+		if rf.Scope == "" {
+			rf.Scope = InitScopeSamePackage
+		}
+		if rf.AllowedFrom == nil {
+			rf.AllowedFrom = []*regexp.Regexp{}
+		}
+	}
+	if c.Generated.Generators == nil {
+		c.Generated.Generators = []*GeneratorPolicy{}
+	}
+	for i, g := range c.Generated.Generators {
+		if g.Pattern == nil {
+			return fmt.Errorf("invalid generated.generators[%d]: pattern is required", i)
+		}
+		if g.DisabledRules == nil {
+			g.DisabledRules = []string{}
+		}
+	}
+	if c.Tests != nil {
+		if c.Tests.InitScope == "" {
+			c.Tests.InitScope = c.InitScope
+		}
+		if c.Tests.MutationScope == "" {
+			c.Tests.MutationScope = c.MutationScope
+		}
+		if c.Tests.CopyScope == "" {
+			c.Tests.CopyScope = c.CopyScope
+		}
+		if c.Tests.FixturePackages == nil {
+			c.Tests.FixturePackages = []*regexp.Regexp{}
+		}
 	}
 
 	// Validate scopes
-	if err := validateInitScope(initScope); err != nil {
-		return nil, err
+	if err := validateInitScope("init-scope", c.InitScope); err != nil {
+		return err
 	}
-	if err := validateMutationScope(mutationScope); err != nil {
-		return nil, err
+	if err := validateMutationScope("mutation-scope", c.MutationScope); err != nil {
+		return err
+	}
+	if err := validateCopyScope("copy-scope", c.CopyScope); err != nil {
+		return err
+	}
+	if err := validateClosures("closures", c.Closures); err != nil {
+		return err
+	}
+	// Without validation methods, only an error result or an annotation
+	// would satisfy these rules
+	if len(c.ValidateMethods) == 0 && (c.RequireValidation || c.RequireInvariantCheck) {
+		key := "require-validation"
+		if !c.RequireValidation {
+			key = "require-invariant-check"
+		}
+		return fmt.Errorf("invalid validate-methods: at least one pattern is required with %s", key)
+	}
+	if c.Tests != nil {
+		if err := validateInitScope("tests.init-scope", c.Tests.InitScope); err != nil {
+			return err
+		}
+		if err := validateMutationScope("tests.mutation-scope", c.Tests.MutationScope); err != nil {
+			return err
+		}
+		if err := validateCopyScope("tests.copy-scope", c.Tests.CopyScope); err != nil {
+			return err
+		}
+	}
+	for i, rf := range c.RestrictedFunctions {
+		if err := validateInitScope(fmt.Sprintf("restricted-functions[%d].scope", i), rf.Scope); err != nil {
+			return err
+		}
+	}
+	if err := validateRuleCodes("disabled-rules", c.DisabledRules); err != nil {
+		return err
+	}
+	for i, f := range c.IgnoreFiles {
+		if err := validateRuleCodes(fmt.Sprintf("ignore-files[%d].rules", i), f.Rules); err != nil {
+			return err
+		}
+	}
+	for i, g := range c.Generated.Generators {
+		if err := validateRuleCodes(fmt.Sprintf("generated.generators[%d].disabled-rules", i), g.DisabledRules); err != nil {
+			return err
+		}
 	}
 
-	return &Config{
-		TargetPackages: targetPackages,
-		ExcludeStructs: excludeStructs,
-		FactoryNames:   factoryNames,
-		InitScope:      initScope,
-		MutationScope:  mutationScope,
-		IgnoreFiles:    ignoreFiles,
-	}, nil
+	return nil
 }
 
-func validateInitScope(scope InitScope) error {
+// validateRuleCodes checks that codes are rules of the analyzers. Advice
+// (GS005) is only given when goseal advise is run, so it cannot be disabled.
+func validateRuleCodes(key string, codes []string) error {
+	for _, code := range codes {
+		rule, ok := LookupRule(code)
+		if !ok {
+			return fmt.Errorf("invalid %s entry: %s (unknown rule code)", key, code)
+		}
+		if rule.Analyzer == "" {
+			return fmt.Errorf("invalid %s entry: %s (only reported by goseal %s, which is run explicitly)", key, code, rule.Option)
+		}
+	}
+	return nil
+}
+
+func validateInitScope(key string, scope InitScope) error {
 	switch scope {
 	case InitScopeAny, InitScopeInTargetPackages, InitScopeSamePackage:
 		return nil
 	default:
-		return fmt.Errorf("invalid init-scope: %s (must be 'any', 'in-target-packages', or 'same-package')", scope)
+		return fmt.Errorf("invalid %s: %s (must be 'any', 'in-target-packages', or 'same-package')", key, scope)
 	}
 }
 
-func validateMutationScope(scope MutationScope) error {
+func validateMutationScope(key string, scope MutationScope) error {
 	switch scope {
 	case MutationScopeAny, MutationScopeInTargetPackages, MutationScopeReceiver, MutationScopeSamePackage, MutationScopeNever:
 		return nil
 	default:
-		return fmt.Errorf("invalid mutation-scope: %s (must be 'any', 'in-target-packages', 'receiver', 'same-package', or 'never')", scope)
+		return fmt.Errorf("invalid %s: %s (must be 'any', 'in-target-packages', 'receiver', 'same-package', or 'never')", key, scope)
+	}
+}
+
+func validateCopyScope(key string, scope CopyScope) error {
+	switch scope {
+	case CopyScopeAny, CopyScopeInTargetPackages, CopyScopeSamePackage:
+		return nil
+	default:
+		return fmt.Errorf("invalid %s: %s (must be 'any', 'in-target-packages', or 'same-package')", key, scope)
+	}
+}
+
+func validateClosures(key string, policy ClosurePolicy) error {
+	switch policy {
+	case ClosuresInherit, ClosuresDeny, ClosuresSamePackageOnly:
+		return nil
+	default:
+		return fmt.Errorf("invalid %s: %s (must be 'inherit', 'deny', or 'same-package-only')", key, policy)
 	}
 }
 
@@ -199,19 +819,11 @@ func ParseFromYAML(data []byte) (*Config, error) {
 	}
 
 	// Recover defaults for fully empty YAML
-	finalCfg, err := NewConfig(
-		cfg.TargetPackages,
-		cfg.ExcludeStructs,
-		cfg.FactoryNames,
-		cfg.InitScope,
-		cfg.MutationScope,
-		cfg.IgnoreFiles,
-	)
-	if err != nil {
+	if err := cfg.normalize(); err != nil {
 		return nil, err
 	}
 
-	return finalCfg, nil
+	return &cfg, nil
 }
 
 func ParseConfig(path string) (*Config, error) {
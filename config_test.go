@@ -0,0 +1,195 @@
+package goseal_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/jimmysharp/goseal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFromYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "empty",
+			data: "",
+		},
+		{
+			name: "valid",
+			data: "target-packages:\n  - \"domain\"\nmutation-scope: never\n",
+		},
+		{
+			name:    "unknown key",
+			data:    "mutation_scope: any\n",
+			wantErr: `unknown config key "mutation_scope"`,
+		},
+		{
+			name:    "invalid scope",
+			data:    "init-scope: everywhere\n",
+			wantErr: "invalid init-scope: everywhere",
+		},
+		{
+			name:    "invalid tests scope",
+			data:    "tests:\n  mutation-scope: sometimes\n",
+			wantErr: "invalid tests.mutation-scope: sometimes",
+		},
+		{
+			name:    "unknown tests key",
+			data:    "tests:\n  init_scope: any\n",
+			wantErr: `unknown config key "init_scope"`,
+		},
+		{
+			name:    "struct without pattern",
+			data:    "structs:\n  - init-allowed-from: [\"infra\"]\n",
+			wantErr: "invalid structs[0]: pattern is required",
+		},
+		{
+			name:    "invalid struct pattern",
+			data:    "structs:\n  - pattern: \"User\"\n    mutation-allowed-from: [\"(\"]\n",
+			wantErr: "invalid structs[0].mutation-allowed-from pattern '('",
+		},
+		{
+			name:    "invalid restricted function scope",
+			data:    "restricted-functions:\n  - pattern: \"Reconstitute.*\"\n    scope: receiver\n",
+			wantErr: "invalid restricted-functions[0].scope: receiver",
+		},
+		{
+			name:    "invalid copy scope",
+			data:    "copy-scope: receiver\n",
+			wantErr: "invalid copy-scope: receiver",
+		},
+		{
+			name:    "invalid closures",
+			data:    "closures: receiver\n",
+			wantErr: "invalid closures: receiver",
+		},
+		{
+			name:    "invalid pattern",
+			data:    "factory-names:\n  - \"(\"\n",
+			wantErr: "invalid factory-names pattern '('",
+		},
+		{
+			name:    "generator without pattern",
+			data:    "generated:\n  generators:\n    - check: true\n",
+			wantErr: "invalid generated.generators[0]: pattern is required",
+		},
+		{
+			name:    "unknown generator rule",
+			data:    "generated:\n  generators:\n    - pattern: sqlc\n      disabled-rules: [GS999]\n",
+			wantErr: "invalid generated.generators[0].disabled-rules entry: GS999 (unknown rule code)",
+		},
+		{
+			name:    "require-validation without validate-methods",
+			data:    "require-validation: true\n",
+			wantErr: "invalid validate-methods: at least one pattern is required with require-validation",
+		},
+		{
+			name:    "require-invariant-check without validate-methods",
+			data:    "require-invariant-check: true\nvalidate-methods: []\n",
+			wantErr: "invalid validate-methods: at least one pattern is required with require-invariant-check",
+		},
+		{
+			name:    "advise rule",
+			data:    "disabled-rules: [GS005]\n",
+			wantErr: "invalid disabled-rules entry: GS005 (only reported by goseal advise, which is run explicitly)",
+		},
+		{
+			name:    "ignore file without pattern",
+			data:    "ignore-files:\n  - rules: [GS001]\n",
+			wantErr: "invalid ignore-files[0]: pattern or glob is required",
+		},
+		{
+			name:    "ignore file with pattern and glob",
+			data:    "ignore-files:\n  - pattern: mocks\n    glob: \"**/mocks/*.go\"\n",
+			wantErr: "invalid ignore-files[0]: set either pattern or glob, not both",
+		},
+		{
+			name:    "invalid ignore file glob",
+			data:    "ignore-files:\n  - glob: \"mocks/[\"\n",
+			wantErr: "invalid ignore-files[0].glob 'mocks/['",
+		},
+		{
+			name:    "unknown ignore file rule",
+			data:    "ignore-files:\n  - glob: \"**/*.go\"\n    rules: [GS999]\n",
+			wantErr: "invalid ignore-files[0].rules entry: GS999 (unknown rule code)",
+		},
+		{
+			name:    "unknown ignore file key",
+			data:    "ignore-files:\n  - glob: \"**/*.go\"\n    rule: [GS001]\n",
+			wantErr: `unknown config key "rule"`,
+		},
+		{
+			name:    "invalid validate method pattern",
+			data:    "validate-methods:\n  - \"(\"\n",
+			wantErr: "invalid validate-methods pattern '('",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := goseal.ParseFromYAML([]byte(tt.data))
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	schema, err := goseal.JSONSchema()
+	require.NoError(t, err)
+
+	// The committed schema must be regenerated with `go generate` when the config changes.
+	committed, err := os.ReadFile("goseal.schema.json")
+	require.NoError(t, err)
+	require.Equal(t, string(committed), string(schema))
+
+	// Every config key must be described by the schema.
+	var parsed struct {
+		Properties map[string]any `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(schema, &parsed))
+
+	config, err := goseal.ParseFromYAML(nil)
+	require.NoError(t, err)
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	var keys map[string]any
+	require.NoError(t, json.Unmarshal(data, &keys))
+
+	for key := range keys {
+		require.Contains(t, parsed.Properties, key)
+	}
+	// Optional sections are omitted from the default config, so check the
+	// remaining schema properties by parsing them.
+	for key := range parsed.Properties {
+		_, err := goseal.ParseFromYAML([]byte(key + ": null\n"))
+		require.NoError(t, err, "schema property %q is not a config key", key)
+	}
+}
+
+func TestIgnoreFilesRoundTrip(t *testing.T) {
+	config, err := goseal.ParseFromYAML([]byte(`ignore-files:
+  - "_mock\\.go$"
+  - glob: "**/mocks/*.go"
+  - pattern: "^internal/fixtures/"
+    rules: [GS001, GS003]
+`))
+	require.NoError(t, err)
+
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	var keys map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &keys))
+	require.JSONEq(t, `[
+		"_mock\\.go$",
+		{"glob": "**/mocks/*.go"},
+		{"pattern": "^internal/fixtures/", "rules": ["GS001", "GS003"]}
+	]`, string(keys["ignore-files"]))
+}
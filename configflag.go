@@ -0,0 +1,60 @@
+package goseal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// WithConfigFlag makes the analyzer read its configuration when it first
+// runs, instead of using the one passed to NewAnalyzer: from the file named
+// by its -config flag, or else from the nearest .goseal.yml in the directory
+// of the analyzed package or its parents up to the module root. Use it with
+// drivers that parse analyzer flags, such as unitchecker.
+func WithConfigFlag() Option {
+	return func(c *goseal) {
+		c.configFlag = true
+	}
+}
+
+// loadConfig reads the configuration for WithConfigFlag on the first call.
+func (c *goseal) loadConfig(pass *analysis.Pass) error {
+	c.configOnce.Do(func() {
+		path := c.configPath
+		if path == "" && len(pass.Files) > 0 {
+			path = discoverConfig(filepath.Dir(pass.Fset.File(pass.Files[0].Pos()).Name()))
+		}
+		if path == "" {
+			c.config, c.configErr = NewConfig(nil, nil, nil, "", "", nil)
+			return
+		}
+		config, err := ParseConfig(path)
+		if err != nil {
+			c.configErr = fmt.Errorf("%s: %w", path, err)
+			return
+		}
+		c.config = config
+	})
+	return c.configErr
+}
+
+// discoverConfig returns the path of the nearest .goseal.yml in dir or its
+// parents, stopping at the module root, or "" if there is none.
+func discoverConfig(dir string) string {
+	for {
+		path := filepath.Join(dir, ".goseal.yml")
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,143 @@
+package goseal
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkCopy reports copies of sealed struct values made by node outside the
+// allowed copy scope, like vet's copylocks does for locks.
+func (c *goseal) checkCopy(node ast.Node, pass *analysis.Pass, stack []ast.Node) {
+	if c.config.CopyScope == CopyScopeAny && (c.config.Tests == nil || c.config.Tests.CopyScope == CopyScopeAny) {
+		return
+	}
+
+	switch n := node.(type) {
+	case *ast.AssignStmt:
+		for i, rhs := range n.Rhs {
+			if i < len(n.Lhs) && isBlank(n.Lhs[i]) {
+				continue
+			}
+			c.checkCopiedValue(pass, rhs, stack, "assignment")
+		}
+	case *ast.ValueSpec:
+		for i, value := range n.Values {
+			if i < len(n.Names) && isBlank(n.Names[i]) {
+				continue
+			}
+			c.checkCopiedValue(pass, value, stack, "variable declaration")
+		}
+	case *ast.CallExpr:
+		if tv, ok := pass.TypesInfo.Types[n.Fun]; ok && tv.IsType() {
+			// Conversions create a new value
+			return
+		}
+		for _, arg := range n.Args {
+			c.checkCopiedValue(pass, arg, stack, "call of "+types.ExprString(n.Fun))
+		}
+	case *ast.ReturnStmt:
+		for _, result := range n.Results {
+			c.checkCopiedValue(pass, result, stack, "return")
+		}
+	case *ast.CompositeLit:
+		for _, elt := range n.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			c.checkCopiedValue(pass, elt, stack, "composite literal")
+		}
+	case *ast.SendStmt:
+		c.checkCopiedValue(pass, n.Value, stack, "send")
+	case *ast.RangeStmt:
+		if n.Value == nil || isBlank(n.Value) {
+			return
+		}
+		if named := c.sealedValue(pass.TypesInfo.TypeOf(n.Value)); named != nil {
+			c.reportCopy(pass, n.Value, stack, named, "range var "+types.ExprString(n.Value))
+		}
+	}
+}
+
+// checkCopiedValue checks expr, whose value is copied by action.
+func (c *goseal) checkCopiedValue(pass *analysis.Pass, expr ast.Expr, stack []ast.Node, action string) {
+	switch ast.Unparen(expr).(type) {
+	case *ast.CompositeLit, *ast.CallExpr:
+		// New values are not copies of existing ones
+		return
+	}
+	if named := c.sealedValue(pass.TypesInfo.TypeOf(expr)); named != nil {
+		c.reportCopy(pass, expr, stack, named, action)
+	}
+}
+
+func (c *goseal) reportCopy(pass *analysis.Pass, node ast.Node, stack []ast.Node, named *types.Named, action string) {
+	loc := c.locate(pass, node)
+	copyScope, option := c.copyScope(loc)
+	f := finding{rule: RuleCopyScope, node: node, stack: stack, target: qualifiedName(named.Obj())}
+
+	if !c.isCopyAllowedByScope(copyScope, loc, named.Obj().Pkg().Path()) {
+		c.report(
+			pass,
+			f,
+			"%s copies sealed struct %s by value, which is not allowed %s (%s: %s)",
+			action,
+			named.Obj().Name(),
+			copyScopeDescription(copyScope),
+			option,
+			copyScope,
+		)
+		return
+	}
+
+	c.record(pass, f, true)
+}
+
+// sealedValue returns the sealed struct type of typ if it is a struct value, not a pointer.
+func (c *goseal) sealedValue(typ types.Type) *types.Named {
+	if typ == nil || isPointer(typ) {
+		return nil
+	}
+	return c.sealedStruct(typ)
+}
+
+func (c *goseal) isCopyAllowedByScope(scope CopyScope, loc location, structPkg string) bool {
+	switch scope {
+	case CopyScopeAny:
+		return true
+
+	case CopyScopeInTargetPackages:
+		return loc.fixture || c.isTargetPackage(loc.pkg)
+
+	case CopyScopeSamePackage:
+		return loc.fixture || loc.pkg == structPkg
+
+	default:
+		return false
+	}
+}
+
+// copyScope returns the copy scope for code at loc and the option that sets it.
+func (c *goseal) copyScope(loc location) (CopyScope, string) {
+	if loc.test {
+		return c.config.Tests.CopyScope, "tests.copy-scope"
+	}
+	return c.config.CopyScope, "copy-scope"
+}
+
+func copyScopeDescription(scope CopyScope) string {
+	switch scope {
+	case CopyScopeSamePackage:
+		return "from outside its package"
+	case CopyScopeInTargetPackages:
+		return "from outside target packages"
+	default:
+		return "in this scope"
+	}
+}
+
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "_"
+}
@@ -0,0 +1,65 @@
+package goseal
+
+import (
+	"go/token"
+	"sync"
+)
+
+// Decision is the outcome of checking a single construction or mutation of
+// a sealed struct, or a call to a restricted function, whether it was allowed or not.
+type Decision struct {
+	Rule     Rule           // Violated rule, or the last rule checked when allowed
+	Allowed  bool           // Whether the code is allowed by the config
+	Position token.Position // Position of the checked node
+	Package  string         // Package containing the checked node
+	Function string         // Enclosing function, as in baseline entries
+	Struct   string         // Qualified name of the sealed struct or restricted function
+	Field    string         // Assigned field, if any
+}
+
+// Recorder collects the decisions made by analyzers created with WithRecorder.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu        sync.Mutex
+	decisions []Decision
+	seen      map[decisionKey]bool
+}
+
+type decisionKey struct {
+	position string
+	rule     string
+	field    string
+}
+
+// Decisions returns the recorded decisions. Decisions for the same node seen
+// in several variants of a package (e.g. with test files) are recorded once.
+func (r *Recorder) Decisions() []Decision {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Decision(nil), r.decisions...)
+}
+
+func (r *Recorder) record(d Decision) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := decisionKey{position: d.Position.String(), rule: d.Rule.Code, field: d.Field}
+	if r.seen == nil {
+		r.seen = make(map[decisionKey]bool)
+	}
+	if r.seen[key] {
+		return
+	}
+	r.seen[key] = true
+	r.decisions = append(r.decisions, d)
+}
+
+// Option configures an analyzer created by NewAnalyzer.
+type Option func(*goseal)
+
+// WithRecorder makes the analyzer record every decision to r, including allowed ones.
+func WithRecorder(r *Recorder) Option {
+	return func(c *goseal) {
+		c.recorder = r
+	}
+}
@@ -0,0 +1,3 @@
+package goseal
+
+var GraphAction = graphAction
@@ -0,0 +1,289 @@
+package goseal
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// requiresAllFields reports whether require-all-fields applies to the sealed struct obj.
+func (c *goseal) requiresAllFields(obj *types.TypeName) bool {
+	if c.config.RequireAllFields {
+		return true
+	}
+	for _, sc := range c.config.structConfigs(qualifiedName(obj)) {
+		if sc.RequireAllFields {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAllFieldsSet reports a composite literal of a sealed struct in a
+// factory function that leaves fields unset, except those tagged goseal:"optional".
+func (c *goseal) checkAllFieldsSet(lit *ast.CompositeLit, pass *analysis.Pass, stack []ast.Node, named *types.Named) {
+	fn := c.getEnclosingFunc(stack)
+	if fn == nil || !c.requiresAllFields(named.Obj()) {
+		return
+	}
+	st := named.Underlying().(*types.Struct)
+
+	// Unkeyed literals must set every field to compile, unless they are empty
+	set := make(map[string]bool)
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok {
+			set[key.Name] = true
+		}
+	}
+
+	var missing []string
+	for i := range st.NumFields() {
+		field := st.Field(i)
+		if field.Name() == "_" || set[field.Name()] {
+			continue
+		}
+		if tag, ok := fieldTag(named, field); ok {
+			if policy, err := parseFieldTag(tag); err == nil && policy.optional {
+				continue
+			}
+		}
+		missing = append(missing, field.Name())
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	c.report(
+		pass,
+		finding{rule: RuleRequireAllFields, node: lit, stack: stack, target: qualifiedName(named.Obj())},
+		"factory %s does not set %s %s of sealed struct %s (require-all-fields); set %s or tag %[5]s goseal:\"optional\"",
+		fn.Name.Name,
+		plural(len(missing), "field", "fields"),
+		strings.Join(missing, ", "),
+		named.Obj().Name(),
+		plural(len(missing), "it", "them"),
+	)
+}
+
+// checkFactoryValidation reports a factory function constructing sealed
+// structs of its package that neither returns an error, nor validates them,
+// nor is annotated //goseal:novalidate. A construction is validated if it is
+// stored in a variable on which a validation method is called afterwards,
+// with the result checked (or without results); literals nested in another
+// construction are validated with it.
+func (c *goseal) checkFactoryValidation(fn *ast.FuncDecl, pass *analysis.Pass, stack []ast.Node) {
+	if !c.config.RequireValidation || fn.Body == nil || hasDirective(fn.Doc, "goseal:novalidate") || returnsError(fn, pass) {
+		return
+	}
+	if !c.requiresFactory(c.locate(pass, fn)) || !c.isInAllowedFactory(stack) {
+		return
+	}
+
+	type construction struct {
+		lit   *ast.CompositeLit
+		named *types.Named
+	}
+	var constructed []construction
+	bound := make(map[*ast.CompositeLit]*types.Var) // Constructions stored in a variable
+	validations := make(map[*types.Var][]token.Pos) // Checked validation calls by receiver variable
+
+	bind := func(lhs ast.Expr, rhs ast.Expr) {
+		lit := constructionLit(rhs)
+		id, ok := ast.Unparen(lhs).(*ast.Ident)
+		if lit == nil || !ok {
+			return
+		}
+		if v, ok := pass.TypesInfo.ObjectOf(id).(*types.Var); ok {
+			bound[lit] = v
+		}
+	}
+
+	var body []ast.Node
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if n == nil {
+			body = body[:len(body)-1]
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Constructions in function literals that are not factory code are reported as GS002
+			if c.config.Closures == ClosuresDeny && len(c.config.FactoryNames) > 0 {
+				return false
+			}
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i := range n.Lhs {
+					bind(n.Lhs[i], n.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == len(n.Values) {
+				for i := range n.Names {
+					bind(n.Names[i], n.Values[i])
+				}
+			}
+		case *ast.CompositeLit:
+			named := c.ownSealedStruct(pass.TypesInfo.TypeOf(n), pass)
+			if named != nil && !c.inOwnConstruction(body, pass) {
+				constructed = append(constructed, construction{lit: n, named: named.Origin()})
+			}
+		case *ast.CallExpr:
+			if v := c.validatedVar(n, pass); v != nil && resultChecked(n, body, pass) {
+				validations[v] = append(validations[v], n.Pos())
+			}
+		}
+		body = append(body, n)
+		return true
+	})
+
+	var unvalidated []*types.Named
+	for _, con := range constructed {
+		v := bound[con.lit]
+		if v != nil && slices.ContainsFunc(validations[v], func(pos token.Pos) bool { return pos > con.lit.Pos() }) {
+			continue
+		}
+		if !slices.Contains(unvalidated, con.named) {
+			unvalidated = append(unvalidated, con.named)
+		}
+	}
+
+	for _, named := range unvalidated {
+		c.report(
+			pass,
+			finding{rule: RuleRequireValidation, node: fn.Name, stack: stack, target: qualifiedName(named.Obj())},
+			"factory %s does not validate sealed struct %s (require-validation); return an error, call a method matching validate-methods, or annotate it //goseal:novalidate",
+			fn.Name.Name,
+			named.Obj().Name(),
+		)
+	}
+}
+
+// constructionLit returns the composite literal constructed by e, such as
+// User{...} or &User{...}, or nil.
+func constructionLit(e ast.Expr) *ast.CompositeLit {
+	e = ast.Unparen(e)
+	if u, ok := e.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		e = ast.Unparen(u.X)
+	}
+	lit, _ := e.(*ast.CompositeLit)
+	return lit
+}
+
+// inOwnConstruction reports whether stack is inside a composite literal of a
+// sealed struct of pass's package.
+func (c *goseal) inOwnConstruction(stack []ast.Node, pass *analysis.Pass) bool {
+	for _, n := range stack {
+		if lit, ok := n.(*ast.CompositeLit); ok && c.ownSealedStruct(pass.TypesInfo.TypeOf(lit), pass) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// validatedVar returns the variable holding a sealed struct on which call
+// calls a validation method, such as u in u.validate(), or nil.
+func (c *goseal) validatedVar(call *ast.CallExpr, pass *analysis.Pass) *types.Var {
+	fun, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || !matchesAny(c.config.ValidateMethods, fun.Sel.Name) {
+		return nil
+	}
+	sel := pass.TypesInfo.Selections[fun]
+	if sel == nil || sel.Kind() != types.MethodVal || c.sealedStruct(sel.Recv()) == nil {
+		return nil
+	}
+
+	recv := ast.Unparen(fun.X)
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = ast.Unparen(star.X)
+	}
+	id, ok := recv.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	v, _ := pass.TypesInfo.Uses[id].(*types.Var)
+	return v
+}
+
+// resultChecked reports whether the results of call, whose parents are
+// stack, are used: not discarded by an expression, go or defer statement, or
+// assigned only to blank identifiers. Calls without results are checked.
+func resultChecked(call *ast.CallExpr, stack []ast.Node, pass *analysis.Pass) bool {
+	if tuple, ok := pass.TypesInfo.TypeOf(call).(*types.Tuple); ok && tuple.Len() == 0 {
+		return true
+	}
+
+	var parent ast.Node
+	for i := len(stack) - 1; i >= 0; i-- {
+		if _, ok := stack[i].(*ast.ParenExpr); !ok {
+			parent = stack[i]
+			break
+		}
+	}
+	switch parent := parent.(type) {
+	case *ast.ExprStmt, *ast.GoStmt, *ast.DeferStmt:
+		return false
+	case *ast.AssignStmt:
+		return !allBlank(parent.Lhs)
+	case *ast.ValueSpec:
+		var names []ast.Expr
+		for _, name := range parent.Names {
+			names = append(names, name)
+		}
+		return !allBlank(names)
+	}
+	return true
+}
+
+// allBlank reports whether every expression of exprs is the blank identifier.
+func allBlank(exprs []ast.Expr) bool {
+	for _, e := range exprs {
+		if id, ok := ast.Unparen(e).(*ast.Ident); !ok || id.Name != "_" {
+			return false
+		}
+	}
+	return true
+}
+
+// returnsError reports whether fn has an error result.
+func returnsError(fn *ast.FuncDecl, pass *analysis.Pass) bool {
+	obj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+	results := obj.Signature().Results()
+	for i := range results.Len() {
+		if types.Identical(results.At(i).Type(), types.Universe.Lookup("error").Type()) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDirective reports whether doc contains the comment directive //name.
+func hasDirective(doc *ast.CommentGroup, name string) bool {
+	if doc == nil {
+		return false
+	}
+	for _, comment := range doc.List {
+		text, ok := strings.CutPrefix(comment.Text, "//"+name)
+		if ok && (text == "" || text[0] == ' ') {
+			return true
+		}
+	}
+	return false
+}
+
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
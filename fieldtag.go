@@ -0,0 +1,114 @@
+package goseal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// fieldPolicy is the policy of a field set by its goseal struct tag.
+type fieldPolicy struct {
+	unsealed bool          // goseal:"-": the field may be assigned anywhere
+	scope    MutationScope // Scope for assignments to the field, if set
+	tag      string        // Option that set the scope, e.g. "readonly" or "mutation=same-package"
+	optional bool          // Factories may leave the field unset (require-all-fields)
+}
+
+// parseFieldTag parses the value of a goseal struct tag, a comma-separated
+// list of options:
+//
+//	goseal:"-"                        not sealed
+//	goseal:"readonly"                 never assigned after construction
+//	goseal:"mutation=same-package"    assigned within the given mutation scope
+//	goseal:"optional"                 may be left unset by factories
+func parseFieldTag(tag string) (fieldPolicy, error) {
+	if tag == "-" {
+		return fieldPolicy{unsealed: true}, nil
+	}
+
+	var policy fieldPolicy
+	for option := range strings.SplitSeq(tag, ",") {
+		switch {
+		case option == "readonly":
+			policy.scope, policy.tag = MutationScopeNever, option
+		case option == "optional":
+			policy.optional = true
+		case strings.HasPrefix(option, "mutation="):
+			scope := MutationScope(strings.TrimPrefix(option, "mutation="))
+			if err := validateMutationScope("mutation", scope); err != nil {
+				return fieldPolicy{}, err
+			}
+			policy.scope, policy.tag = scope, option
+		default:
+			return fieldPolicy{}, fmt.Errorf("unknown option %q (must be '-', 'readonly', 'mutation=<scope>', or 'optional')", option)
+		}
+	}
+	return policy, nil
+}
+
+// fieldTag returns the goseal tag of field if it is declared directly in the struct named.
+func fieldTag(named *types.Named, field types.Object) (string, bool) {
+	v, ok := field.(*types.Var)
+	if !ok {
+		return "", false
+	}
+	// Fields of instantiated generic structs are distinct objects from their origin's
+	st, ok := named.Origin().Underlying().(*types.Struct)
+	if !ok {
+		return "", false
+	}
+	for i := range st.NumFields() {
+		if st.Field(i) == v.Origin() {
+			return reflect.StructTag(st.Tag(i)).Lookup("goseal")
+		}
+	}
+	return "", false
+}
+
+// checkFieldTags reports invalid goseal tags on the fields of a sealed struct declaration.
+func (c *goseal) checkFieldTags(spec *ast.TypeSpec, pass *analysis.Pass, stack []ast.Node) {
+	obj, ok := pass.TypesInfo.Defs[spec.Name].(*types.TypeName)
+	if !ok || obj.IsAlias() || !c.isSealedType(obj) {
+		return
+	}
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+
+	// Fields are declared at their name, or at the type name if embedded
+	idents := make(map[token.Pos]*ast.Ident)
+	ast.Inspect(spec.Type, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			idents[id.Pos()] = id
+		}
+		return true
+	})
+
+	for i := range st.NumFields() {
+		field := st.Field(i)
+		tag, ok := reflect.StructTag(st.Tag(i)).Lookup("goseal")
+		if !ok {
+			continue
+		}
+		ident := idents[field.Pos()]
+		if ident == nil {
+			continue
+		}
+		if _, err := parseFieldTag(tag); err != nil {
+			c.report(
+				pass,
+				finding{rule: RuleFieldTags, node: ident, stack: stack, target: qualifiedName(obj), field: field.Name()},
+				"invalid goseal tag on field %s of sealed struct %s: %v; the field keeps the mutation scope of the struct",
+				field.Name(),
+				obj.Name(),
+				err,
+			)
+		}
+	}
+}
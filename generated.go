@@ -0,0 +1,60 @@
+package goseal
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// generator returns the name of the tool that generated file according to
+// its "// Code generated ... DO NOT EDIT." header (https://go.dev/s/generatedcode),
+// e.g. "sqlc" for "// Code generated by sqlc. DO NOT EDIT.", and whether file
+// is generated at all. The name is empty if the header does not name a tool.
+func generator(file *ast.File) (string, bool) {
+	if !ast.IsGenerated(file) {
+		return "", false
+	}
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			text, ok := strings.CutPrefix(comment.Text, "// Code generated ")
+			if !ok {
+				continue
+			}
+			text, ok = strings.CutSuffix(text, "DO NOT EDIT.")
+			if !ok {
+				continue
+			}
+			name, ok := strings.CutPrefix(text, "by ")
+			if !ok {
+				return "", true
+			}
+			return strings.TrimRight(name, " .,;:"), true
+		}
+	}
+	return "", true
+}
+
+// generatedPolicy returns whether files generated by the named tool are
+// checked, and the rules disabled in them.
+func (c *goseal) generatedPolicy(name string) (bool, []string) {
+	for _, g := range c.config.Generated.Generators {
+		if g.Pattern.MatchString(name) {
+			return g.Check, g.DisabledRules
+		}
+	}
+	return c.config.Generated.Check, nil
+}
+
+// isRuleDisabledAt reports whether rule is disabled, globally or for the file containing pos.
+func (c *goseal) isRuleDisabledAt(pass *analysis.Pass, rule Rule, pos token.Pos) bool {
+	if c.config.isRuleDisabled(rule) {
+		return true
+	}
+	return slices.Contains(c.policy(pass).fileRules[pass.Fset.File(pos)], rule.Code)
+}
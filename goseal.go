@@ -1,116 +1,102 @@
 package goseal
 
 import (
+	"fmt"
 	"go/ast"
 	"go/types"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
 
 	"golang.org/x/tools/go/analysis"
-	"golang.org/x/tools/go/ast/inspector"
 )
 
 type goseal struct {
-	config *Config
+	config   *Config
+	baseline baselineState
+	recorder *Recorder
+	matches  matchCache
+
+	// Analyzer resolving the policy shared by the checks
+	policyAnalyzer *analysis.Analyzer
+
+	// Module root directories, or "", keyed by directories of analyzed files
+	moduleRoots sync.Map
+
+	// Configuration read on the first run, for WithConfigFlag
+	configFlag bool
+	configPath string
+	configOnce sync.Once
+	configErr  error
 }
 
-func NewAnalyzer(config *Config) *analysis.Analyzer {
-	c := &goseal{
-		config: config,
-	}
-
-	return &analysis.Analyzer{
-		Name: "goseal",
-		Doc:  "Checks that structs are only constructed via factory functions",
-		Run:  c.run,
-	}
+// NewAnalyzer returns an analyzer running all checks of NewAnalyzers in one pass.
+func NewAnalyzer(config *Config, opts ...Option) *analysis.Analyzer {
+	c := newGoseal(config, opts...)
+	return c.analyzer("Checks that structs are only constructed via factory functions", c.run)
 }
 
 func (c *goseal) run(pass *analysis.Pass) (any, error) {
-	// Filter out generated files
-	var userFiles []*ast.File
-	for _, f := range pass.Files {
-		if !isGeneratedFile(f) {
-			userFiles = append(userFiles, f)
-		}
-	}
-
-	// If all files are generated, skip this package
-	if len(userFiles) == 0 {
+	p := c.policy(pass)
+	if p.skip {
 		return nil, nil
 	}
 
-	inspect := inspector.New(userFiles)
-
-	nodeFilter := []ast.Node{
-		(*ast.CompositeLit)(nil),
-		(*ast.AssignStmt)(nil),
-		(*ast.FuncDecl)(nil),
+	var nodes []ast.Node
+	for _, ch := range checks {
+		nodes = append(nodes, ch.nodes...)
 	}
-
-	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
-		if !push {
-			return true
-		}
-
-		filename := pass.Fset.Position(n.Pos()).Filename
-		if c.shouldIgnoreFile(filename) {
-			return false
+	c.inspect(pass, p, nodes, func(n ast.Node, stack []ast.Node) {
+		for _, ch := range checks {
+			ch.visit(c, pass, p, n, stack)
 		}
+	})
 
-		switch node := n.(type) {
-		case *ast.CompositeLit:
-			c.checkCompositeLit(node, pass, stack)
-		case *ast.AssignStmt:
-			c.checkAssignStmt(node, pass, stack)
+	if c.baseline.enabled() {
+		if err := c.finishBaseline(pass); err != nil {
+			return nil, err
 		}
-		return true
-	})
+	}
 
 	return nil, nil
 }
 
-func (c *goseal) isTargetPackage(pkgPath string) bool {
-	// If no target-packages are specified, target all packages
-	if len(c.config.TargetPackages) == 0 {
-		return true
+// finishBaseline reports baseline entries of this package that no longer match a violation.
+// Entries of removed functions are reported at the package clause.
+func (c *goseal) finishBaseline(pass *analysis.Pass) error {
+	declared := declaredFunctions(pass.Files)
+	fixed, err := c.baseline.finish(pass, declared, otherVariantFunctions(pass))
+	if err != nil {
+		return err
 	}
-	// If target-packages are specified, only target matching packages
-	for _, pattern := range c.config.TargetPackages {
-		if pattern.MatchString(pkgPath) {
-			return true
-		}
+	if c.config.isRuleDisabled(RuleBaselineFixed) {
+		return nil
 	}
-	return false
-}
-
-func (c *goseal) isExcludedStruct(structName string) bool {
-	for _, pattern := range c.config.ExcludeStructs {
-		if pattern.MatchString(structName) {
-			return true
-		}
-	}
-	return false
-}
 
-func (c *goseal) shouldIgnoreFile(filename string) bool {
-	for _, pattern := range c.config.IgnoreFiles {
-		if pattern.MatchString(filename) {
-			return true
+	for _, e := range fixed {
+		target := e.Struct
+		if e.Field != "" {
+			target += "." + e.Field
 		}
-	}
-	return false
-}
-
-func isGeneratedFile(file *ast.File) bool {
-	for _, commentGroup := range file.Comments {
-		for _, comment := range commentGroup.List {
-			text := comment.Text
-			if strings.Contains(text, "Code generated") && strings.Contains(text, "DO NOT EDIT") {
-				return true
-			}
+		pos, ok := declared[e.Function]
+		if !ok {
+			pos = pass.Files[0].Package
 		}
+		pass.Report(analysis.Diagnostic{
+			Pos:      pos,
+			Category: RuleBaselineFixed.Code,
+			URL:      RuleBaselineFixed.URL(),
+			Message: fmt.Sprintf(
+				"baseline entry %s for %s in %s no longer matches a violation; remove it from %s",
+				e.Code,
+				target,
+				e.Function,
+				c.baseline.readPath,
+			),
+		})
 	}
-	return false
+	return nil
 }
 
 func (c *goseal) checkCompositeLit(lit *ast.CompositeLit, pass *analysis.Pass, stack []ast.Node) {
@@ -134,42 +120,72 @@ func (c *goseal) checkCompositeLit(lit *ast.CompositeLit, pass *analysis.Pass, s
 		return
 	}
 
-	pkg := named.Obj().Pkg()
-	if pkg == nil {
+	if !c.isSealedType(named.Obj()) {
 		return
 	}
+	pkgPath := named.Obj().Pkg().Path()
+	structName := named.Obj().Name()
 
-	pkgPath := pkg.Path()
+	loc := c.locate(pass, lit)
 
-	if !c.isTargetPackage(pkgPath) {
+	// Friend packages may construct the struct regardless of scope and factory names
+	if c.isInitAllowedFrom(named.Obj(), loc) {
+		c.record(pass, finding{rule: RuleInitScope, node: lit, stack: stack, target: qualifiedName(named.Obj())}, true)
 		return
 	}
 
-	structName := named.Obj().Name()
+	initScope, option := c.initScope(loc)
 
-	if c.isExcludedStruct(structName) {
+	if !c.isInitAllowedByScope(initScope, loc, pkgPath) {
+		c.report(
+			pass,
+			finding{rule: RuleInitScope, node: lit, stack: stack, target: qualifiedName(named.Obj())},
+			"direct construction of sealed struct %s is not allowed %s (%s: %s)",
+			structName,
+			initScopeDescription(initScope),
+			option,
+			initScope,
+		)
 		return
 	}
 
-	if !c.isInitAllowedByScope(pass.Pkg.Path(), pkgPath) {
-		pass.Reportf(
-			lit.Pos(),
-			"direct construction of sealed struct %s is not allowed %s (init-scope: %s)",
+	// Package-level values and init functions of the owning package may construct the struct if allowed
+	ownInit := c.isInOwnPackageInit(stack, loc, pkgPath)
+
+	if c.requiresFactory(loc) && !ownInit && !c.isInAllowedFactory(stack) {
+		c.report(
+			pass,
+			finding{rule: RuleFactoryNames, node: lit, stack: stack, target: qualifiedName(named.Obj())},
+			"direct construction of sealed struct %s is not allowed outside factory functions (factory-names)",
 			structName,
-			c.initScopeDescription(),
-			c.config.InitScope,
 		)
 		return
 	}
 
-	if !c.isInAllowedFactory(stack) {
-		pass.Reportf(
-			lit.Pos(),
-			"direct construction of sealed struct %s is not allowed outside factory functions (factory-names)",
+	if c.requiresFactory(loc) && len(c.config.FactoryNames) > 0 && c.isInDeniedClosure(stack, loc, pkgPath) {
+		c.report(
+			pass,
+			finding{rule: RuleFactoryNames, node: lit, stack: stack, target: qualifiedName(named.Obj())},
+			"direct construction of sealed struct %s is not allowed in function literals of factory functions (closures: %s)",
 			structName,
+			c.config.Closures,
 		)
 		return
 	}
+
+	c.record(pass, finding{rule: c.lastInitRule(), node: lit, stack: stack, target: qualifiedName(named.Obj())}, true)
+
+	if c.requiresFactory(loc) && !ownInit {
+		c.checkAllFieldsSet(lit, pass, stack, named)
+	}
+}
+
+// lastInitRule returns the last rule checked for an allowed construction.
+func (c *goseal) lastInitRule() Rule {
+	if len(c.config.FactoryNames) == 0 {
+		return RuleInitScope
+	}
+	return RuleFactoryNames
 }
 
 func (c *goseal) checkAssignStmt(stmt *ast.AssignStmt, pass *analysis.Pass, stack []ast.Node) {
@@ -178,56 +194,143 @@ func (c *goseal) checkAssignStmt(stmt *ast.AssignStmt, pass *analysis.Pass, stac
 		if !ok {
 			continue
 		}
+		c.checkFieldMutation(pass, stmt, selector, stack, "direct assignment to")
+	}
+}
 
-		tv, ok := pass.TypesInfo.Types[selector.X]
-		if !ok {
-			continue
-		}
-
-		typ := tv.Type
-		if ptr, ok := typ.(*types.Pointer); ok {
-			typ = ptr.Elem()
-		}
+// checkFieldMutation checks a mutation at node of the field selected by
+// selector, described by action (e.g. "direct assignment to").
+func (c *goseal) checkFieldMutation(pass *analysis.Pass, node ast.Node, selector *ast.SelectorExpr, stack []ast.Node, action string) {
+	tv, ok := pass.TypesInfo.Types[selector.X]
+	if !ok {
+		return
+	}
 
-		named, ok := typ.(*types.Named)
-		if !ok {
-			continue
-		}
+	typ := tv.Type
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
 
-		_, ok = named.Underlying().(*types.Struct)
-		if !ok {
-			continue
-		}
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return
+	}
 
-		pkg := named.Obj().Pkg()
-		if pkg == nil {
-			continue
-		}
+	_, ok = named.Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
 
-		pkgPath := pkg.Path()
+	if !c.isSealedType(named.Obj()) {
+		return
+	}
+	pkgPath := named.Obj().Pkg().Path()
+	structName := named.Obj().Name()
 
-		if !c.isTargetPackage(pkgPath) {
-			continue
+	loc := c.locate(pass, node)
+	mutationScope, option := c.mutationScope(loc)
+	setting := string(mutationScope)
+
+	// A goseal tag on the field takes precedence over the struct-level scope
+	if tag, ok := fieldTag(named, pass.TypesInfo.ObjectOf(selector.Sel)); ok {
+		// A malformed tag is reported by checkFieldTags (GS012) and ignored
+		// here: the field keeps the scope of the struct, so that a typo
+		// never unseals it
+		policy, err := parseFieldTag(tag)
+		switch {
+		case err != nil:
+		case policy.unsealed:
+			return
+		case policy.scope != "":
+			mutationScope, option, setting = policy.scope, "goseal tag", policy.tag
 		}
+	}
 
-		structName := named.Obj().Name()
-
-		if c.isExcludedStruct(structName) {
-			continue
+	if !c.isMutationAllowedFrom(named.Obj(), loc) {
+		var description string
+		switch {
+		case !c.isMutationAllowedByScope(mutationScope, loc, pkgPath, stack):
+			description = mutationScopeDescription(mutationScope)
+		case mutationScope == MutationScopeReceiver && c.isInDeniedClosure(stack, loc, pkgPath):
+			description, option, setting = "in function literals of receiver methods", "closures", string(c.config.Closures)
 		}
-
-		if !c.isMutationAllowedByScope(pass.Pkg.Path(), pkgPath, stack) {
+		if description != "" {
 			fieldName := selector.Sel.Name
-			pass.Reportf(
-				stmt.Pos(),
-				"direct assignment to field %s of sealed struct %s is not allowed %s (mutation-scope: %s)",
+			c.report(
+				pass,
+				finding{rule: RuleMutationScope, node: node, stack: stack, target: qualifiedName(named.Obj()), field: fieldName},
+				"%s field %s of sealed struct %s is not allowed %s (%s: %s)",
+				action,
 				fieldName,
 				structName,
-				c.mutationScopeDescription(),
-				c.config.MutationScope,
+				description,
+				option,
+				setting,
 			)
+			return
 		}
 	}
+
+	c.record(pass, finding{rule: RuleMutationScope, node: node, stack: stack, target: qualifiedName(named.Obj()), field: selector.Sel.Name}, true)
+}
+
+// finding describes a checked construction or mutation of a sealed struct,
+// or a checked call to a restricted function.
+type finding struct {
+	rule   Rule
+	node   ast.Node
+	stack  []ast.Node
+	target string // Qualified name of the sealed struct or restricted function
+	field  string // Assigned field, if any
+}
+
+// report reports f as a violation unless its rule is disabled or it is recorded in the baseline.
+func (c *goseal) report(pass *analysis.Pass, f finding, format string, args ...any) {
+	if c.isRuleDisabledAt(pass, f.rule, f.node.Pos()) {
+		return
+	}
+	c.record(pass, f, false)
+
+	if c.baseline.enabled() {
+		fp := fingerprint{
+			Package:  pass.Pkg.Path(),
+			Function: enclosingFunctionKey(f.stack),
+			Struct:   f.target,
+			Field:    f.field,
+			Code:     f.rule.Code,
+		}
+		if c.baseline.observe(pass, fp) {
+			return
+		}
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      f.node.Pos(),
+		Category: f.rule.Code,
+		URL:      f.rule.URL(),
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// record passes f to the recorder, if any.
+func (c *goseal) record(pass *analysis.Pass, f finding, allowed bool) {
+	if c.recorder == nil {
+		return
+	}
+
+	c.recorder.record(Decision{
+		Rule:     f.rule,
+		Allowed:  allowed,
+		Position: pass.Fset.Position(f.node.Pos()),
+		Package:  pass.Pkg.Path(),
+		Function: enclosingFunctionKey(f.stack),
+		Struct:   f.target,
+		Field:    f.field,
+	})
+}
+
+func qualifiedName(obj *types.TypeName) string {
+	return obj.Pkg().Path() + "." + obj.Name()
 }
 
 func (c *goseal) isInAllowedFactory(stack []ast.Node) bool {
@@ -251,35 +354,35 @@ func (c *goseal) isInAllowedFactory(stack []ast.Node) bool {
 	return false
 }
 
-func (c *goseal) isInitAllowedByScope(currentPkg, structPkg string) bool {
-	switch c.config.InitScope {
+func (c *goseal) isInitAllowedByScope(scope InitScope, loc location, structPkg string) bool {
+	switch scope {
 	case InitScopeAny:
 		return true
 
 	case InitScopeInTargetPackages:
-		return c.isTargetPackage(currentPkg)
+		return loc.fixture || c.isTargetPackage(loc.pkg)
 
 	case InitScopeSamePackage:
-		return currentPkg == structPkg
+		return loc.fixture || loc.pkg == structPkg
 
 	default:
 		return false
 	}
 }
 
-func (c *goseal) isMutationAllowedByScope(currentPkg, structPkg string, stack []ast.Node) bool {
-	switch c.config.MutationScope {
+func (c *goseal) isMutationAllowedByScope(scope MutationScope, loc location, structPkg string, stack []ast.Node) bool {
+	switch scope {
 	case MutationScopeAny:
 		return true
 
 	case MutationScopeInTargetPackages:
-		return c.isTargetPackage(currentPkg)
+		return loc.fixture || c.isTargetPackage(loc.pkg)
 
 	case MutationScopeReceiver:
 		return c.isInReceiverMethod(stack)
 
 	case MutationScopeSamePackage:
-		return currentPkg == structPkg
+		return loc.fixture || loc.pkg == structPkg
 
 	case MutationScopeNever:
 		return false
@@ -289,6 +392,47 @@ func (c *goseal) isMutationAllowedByScope(currentPkg, structPkg string, stack []
 	}
 }
 
+// isInitAllowedFrom reports whether code at loc is in a package allowed to
+// construct obj by init-allowed-from, globally or for the struct.
+func (c *goseal) isInitAllowedFrom(obj *types.TypeName, loc location) bool {
+	return matchesAny(c.initAllowedFrom(obj), loc.pkg)
+}
+
+// isMutationAllowedFrom reports whether code at loc is in a package allowed to
+// mutate obj by mutation-allowed-from, globally or for the struct.
+func (c *goseal) isMutationAllowedFrom(obj *types.TypeName, loc location) bool {
+	return matchesAny(c.mutationAllowedFrom(obj), loc.pkg)
+}
+
+// initAllowedFrom returns the init-allowed-from patterns that apply to obj:
+// the global ones followed by those of its per-struct settings.
+func (c *goseal) initAllowedFrom(obj *types.TypeName) []*regexp.Regexp {
+	patterns := slices.Clone(c.config.InitAllowedFrom)
+	for _, sc := range c.config.structConfigs(qualifiedName(obj)) {
+		patterns = append(patterns, sc.InitAllowedFrom...)
+	}
+	return patterns
+}
+
+// mutationAllowedFrom returns the mutation-allowed-from patterns that apply
+// to obj: the global ones followed by those of its per-struct settings.
+func (c *goseal) mutationAllowedFrom(obj *types.TypeName) []*regexp.Regexp {
+	patterns := slices.Clone(c.config.MutationAllowedFrom)
+	for _, sc := range c.config.structConfigs(qualifiedName(obj)) {
+		patterns = append(patterns, sc.MutationAllowedFrom...)
+	}
+	return patterns
+}
+
+func matchesAny(patterns []*regexp.Regexp, s string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *goseal) isInReceiverMethod(stack []ast.Node) bool {
 	enclosingFunc := c.getEnclosingFunc(stack)
 	if enclosingFunc == nil {
@@ -302,8 +446,84 @@ func (c *goseal) isInReceiverMethod(stack []ast.Node) bool {
 	return true
 }
 
-func (c *goseal) initScopeDescription() string {
-	switch c.config.InitScope {
+// isInDeniedClosure reports whether the top of stack is in a function literal
+// that does not inherit the factory or receiver status of its enclosing
+// function for a sealed struct of structPkg (closures).
+func (c *goseal) isInDeniedClosure(stack []ast.Node, loc location, structPkg string) bool {
+	switch c.config.Closures {
+	case ClosuresDeny:
+	case ClosuresSamePackageOnly:
+		if loc.pkg == structPkg {
+			return false
+		}
+	default:
+		return false
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch stack[i].(type) {
+		case *ast.FuncLit:
+			return true
+		case *ast.FuncDecl:
+			return false
+		}
+	}
+	return false
+}
+
+// location describes the code containing a checked node.
+type location struct {
+	pkg     string // Package path, without the _test suffix for external test packages if configured
+	test    bool   // In a _test.go file or a test fixture package
+	fixture bool   // In a test fixture package
+}
+
+func (c *goseal) locate(pass *analysis.Pass, node ast.Node) location {
+	loc := location{pkg: pass.Pkg.Path()}
+
+	tests := c.config.Tests
+	if tests == nil {
+		return loc
+	}
+
+	if strings.HasSuffix(pass.Fset.File(node.Pos()).Name(), "_test.go") {
+		loc.test = true
+		if tests.ExternalTestPackage {
+			loc.pkg = strings.TrimSuffix(loc.pkg, "_test")
+		}
+	}
+	for _, pattern := range tests.FixturePackages {
+		if pattern.MatchString(pass.Pkg.Path()) {
+			loc.test = true
+			loc.fixture = true
+			break
+		}
+	}
+	return loc
+}
+
+// initScope returns the init scope for code at loc and the option that sets it.
+func (c *goseal) initScope(loc location) (InitScope, string) {
+	if loc.test {
+		return c.config.Tests.InitScope, "tests.init-scope"
+	}
+	return c.config.InitScope, "init-scope"
+}
+
+// mutationScope returns the mutation scope for code at loc and the option that sets it.
+func (c *goseal) mutationScope(loc location) (MutationScope, string) {
+	if loc.test {
+		return c.config.Tests.MutationScope, "tests.mutation-scope"
+	}
+	return c.config.MutationScope, "mutation-scope"
+}
+
+func (c *goseal) requiresFactory(loc location) bool {
+	return !loc.test || c.config.Tests.EnforceFactoryNames
+}
+
+func initScopeDescription(scope InitScope) string {
+	switch scope {
 	case InitScopeSamePackage:
 		return "from outside its package"
 	case InitScopeInTargetPackages:
@@ -313,8 +533,8 @@ func (c *goseal) initScopeDescription() string {
 	}
 }
 
-func (c *goseal) mutationScopeDescription() string {
-	switch c.config.MutationScope {
+func mutationScopeDescription(scope MutationScope) string {
+	switch scope {
 	case MutationScopeReceiver:
 		return "outside its receiver methods"
 	case MutationScopeSamePackage:
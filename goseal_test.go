@@ -1,11 +1,15 @@
 package goseal_test
 
 import (
+	"fmt"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/jimmysharp/goseal"
+	"github.com/jimmysharp/goseal/internal/analyzertest"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -37,6 +41,57 @@ func TestAnalyzer(t *testing.T) {
 		{
 			name: "config/exclude_structs",
 		},
+		{
+			name: "config/disabled_rules",
+		},
+		{
+			name: "config/tests",
+		},
+		{
+			name: "config/allowed_from",
+		},
+		{
+			name: "config/restricted_functions",
+		},
+		{
+			name: "config/field_tags",
+		},
+		{
+			name: "config/aliasing",
+		},
+		{
+			name: "config/method_calls",
+		},
+		{
+			name: "config/copy_scope",
+		},
+		{
+			name: "config/require_all_fields",
+		},
+		{
+			name: "config/require_validation",
+		},
+		{
+			name: "config/invariant_check",
+		},
+		{
+			name: "config/closures_inherit",
+		},
+		{
+			name: "config/closures_deny",
+		},
+		{
+			name: "config/closures_same_package",
+		},
+		{
+			name: "config/package_vars",
+		},
+		{
+			name: "config/generated_policy",
+		},
+		{
+			name: "config/ignore_files",
+		},
 		{
 			name: "unsupported",
 		},
@@ -59,3 +114,135 @@ func TestAnalyzer(t *testing.T) {
 		})
 	}
 }
+
+func TestAnalyzer_DiagnosticCategories(t *testing.T) {
+	testdataDir := filepath.Join(analysistest.TestData(), "basic")
+
+	config, err := goseal.ParseConfig(filepath.Join(testdataDir, ".goseal.yml"))
+	require.NoError(t, err)
+
+	results := analysistest.Run(t, testdataDir, goseal.NewAnalyzer(config), "example.com/testproject/...")
+
+	got := map[string]bool{}
+	for _, result := range results {
+		for _, d := range result.Diagnostics {
+			rule, ok := goseal.LookupRule(d.Category)
+			require.True(t, ok, "unknown category %q for %q", d.Category, d.Message)
+			require.Equal(t, rule.URL(), d.URL)
+			got[d.Category] = true
+		}
+	}
+	require.Equal(t, map[string]bool{
+		goseal.RuleInitScope.Code:     true,
+		goseal.RuleFactoryNames.Code:  true,
+		goseal.RuleMutationScope.Code: true,
+	}, got)
+}
+
+func TestAnalyzer_Baseline(t *testing.T) {
+	testdataDir := filepath.Join(analysistest.TestData(), "baseline")
+
+	config, err := goseal.ParseConfig(filepath.Join(testdataDir, ".goseal.yml"))
+	require.NoError(t, err)
+
+	a := goseal.NewAnalyzer(config)
+	require.NoError(t, a.Flags.Set("baseline", filepath.Join(testdataDir, ".goseal-baseline.json")))
+
+	analysistest.Run(t, testdataDir, a, "example.com/testproject/...")
+}
+
+// discardTesting ignores expectation mismatches so that diagnostics can be inspected directly.
+type discardTesting struct{}
+
+func (discardTesting) Errorf(string, ...any) {}
+
+func TestAnalyzer_WriteBaseline(t *testing.T) {
+	testdataDir := filepath.Join(analysistest.TestData(), "basic")
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+
+	config, err := goseal.ParseConfig(filepath.Join(testdataDir, ".goseal.yml"))
+	require.NoError(t, err)
+
+	// Record all current violations without reporting them.
+	writer := goseal.NewAnalyzer(config)
+	require.NoError(t, writer.Flags.Set("write-baseline", baselinePath))
+	for _, result := range analysistest.Run(discardTesting{}, testdataDir, writer, "example.com/testproject/...") {
+		require.Empty(t, result.Diagnostics)
+	}
+
+	baseline, err := goseal.ReadBaseline(baselinePath)
+	require.NoError(t, err)
+	require.NotEmpty(t, baseline.Entries)
+
+	// With the written baseline, nothing is reported.
+	reader := goseal.NewAnalyzer(config)
+	require.NoError(t, reader.Flags.Set("baseline", baselinePath))
+	for _, result := range analysistest.Run(discardTesting{}, testdataDir, reader, "example.com/testproject/...") {
+		require.Empty(t, result.Diagnostics)
+	}
+}
+
+func TestNewAnalyzers(t *testing.T) {
+	tests := []string{
+		"basic",
+		"config/restricted_functions",
+		"config/aliasing",
+		"config/method_calls",
+		"config/copy_scope",
+		"config/require_validation",
+		"config/invariant_check",
+	}
+	for _, name := range tests {
+		t.Run(name, func(t *testing.T) {
+			testdataDir := filepath.Join(analysistest.TestData(), name)
+
+			config, err := goseal.ParseConfig(filepath.Join(testdataDir, ".goseal.yml"))
+			require.NoError(t, err)
+
+			analyzers := goseal.NewAnalyzers(config)
+			require.NoError(t, analysis.Validate(analyzers))
+			require.Equal(t, "goseal", analyzers[len(analyzers)-1].Name)
+
+			// The split analyzers report what the combined analyzer reports,
+			// each diagnostic by the analyzer of its rule
+			got := runAnalyzers(t, testdataDir, analyzers...)
+			require.NotEmpty(t, got)
+			require.Equal(t, runAnalyzers(t, testdataDir, goseal.NewAnalyzer(config)), got)
+		})
+	}
+}
+
+func TestNewAnalyzers_WriteBaseline(t *testing.T) {
+	testdataDir := filepath.Join(analysistest.TestData(), "basic")
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+
+	config, err := goseal.ParseConfig(filepath.Join(testdataDir, ".goseal.yml"))
+	require.NoError(t, err)
+
+	// Violations of all analyzers are recorded in one baseline
+	writer := goseal.NewAnalyzers(config)
+	require.NoError(t, writer[len(writer)-1].Flags.Set("write-baseline", baselinePath))
+	require.Empty(t, runAnalyzers(t, testdataDir, writer...))
+
+	baseline, err := goseal.ReadBaseline(baselinePath)
+	require.NoError(t, err)
+	require.NotEmpty(t, baseline.Entries)
+
+	// With the written baseline, nothing is reported, not even stale entries
+	reader := goseal.NewAnalyzers(config)
+	require.NoError(t, reader[len(reader)-1].Flags.Set("baseline", baselinePath))
+	require.Empty(t, runAnalyzers(t, testdataDir, reader...))
+}
+
+// runAnalyzers returns the diagnostics of analyzers in the packages of dir,
+// sorted and without the duplicates of test variants.
+func runAnalyzers(t *testing.T, dir string, analyzers ...*analysis.Analyzer) []string {
+	t.Helper()
+
+	var diagnostics []string
+	for _, d := range analyzertest.Run(t, dir, "./...", analyzers...) {
+		diagnostics = append(diagnostics, fmt.Sprintf("%s: %s: %s", d.Position, d.Category, d.Message))
+	}
+	slices.Sort(diagnostics)
+	return slices.Compact(diagnostics)
+}
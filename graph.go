@@ -0,0 +1,116 @@
+package goseal
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Graph records which packages construct or mutate which sealed structs.
+type Graph struct {
+	Packages []string // Packages containing constructions or mutations
+	Structs  []string // Qualified names of the sealed structs
+	Edges    []GraphEdge
+}
+
+// GraphEdge aggregates the decisions with the same package, struct, rule and outcome.
+type GraphEdge struct {
+	From    string // Package
+	To      string // Qualified name of the sealed struct
+	Action  string // "construct", "mutate", "call" or "copy"
+	Rule    Rule
+	Allowed bool
+	Count   int
+}
+
+const (
+	graphActionConstruct = "construct"
+	graphActionMutate    = "mutate"
+	graphActionCall      = "call"
+	graphActionCopy      = "copy"
+)
+
+// BuildGraph analyzes pkgs with config and returns the graph of every
+// construction, mutation, restricted call and copy decision, including
+// allowed ones. Other findings, such as aliasing, are not edges.
+func BuildGraph(config *Config, pkgs []*packages.Package) (*Graph, error) {
+	decisions, err := collectDecisions(config, pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	type edgeKey struct {
+		from, to, code string
+		allowed        bool
+	}
+	edges := make(map[edgeKey]*GraphEdge)
+	pkgSet := make(map[string]bool)
+	structSet := make(map[string]bool)
+
+	for _, d := range decisions {
+		action, ok := graphAction(d.Rule)
+		if !ok {
+			continue
+		}
+		key := edgeKey{from: d.Package, to: d.Struct, code: d.Rule.Code, allowed: d.Allowed}
+		e := edges[key]
+		if e == nil {
+			e = &GraphEdge{
+				From:    d.Package,
+				To:      d.Struct,
+				Action:  action,
+				Rule:    d.Rule,
+				Allowed: d.Allowed,
+			}
+			edges[key] = e
+		}
+		e.Count++
+		pkgSet[d.Package] = true
+		structSet[d.Struct] = true
+	}
+
+	g := &Graph{
+		Packages: slices.Sorted(maps.Keys(pkgSet)),
+		Structs:  slices.Sorted(maps.Keys(structSet)),
+		Edges:    []GraphEdge{},
+	}
+	for _, e := range edges {
+		g.Edges = append(g.Edges, *e)
+	}
+	slices.SortFunc(g.Edges, func(x, y GraphEdge) int {
+		return cmp.Or(
+			cmp.Compare(x.From, y.From),
+			cmp.Compare(x.To, y.To),
+			cmp.Compare(x.Rule.Code, y.Rule.Code),
+			cmp.Compare(boolRank(x.Allowed), boolRank(y.Allowed)),
+		)
+	})
+	return g, nil
+}
+
+// graphAction returns the action of the decisions of rule, or false for
+// rules that are not about constructing, mutating, calling or copying, which
+// are left out of the graph.
+func graphAction(rule Rule) (string, bool) {
+	switch rule {
+	case RuleInitScope, RuleFactoryNames, RuleRequireAllFields, RuleRequireValidation:
+		return graphActionConstruct, true
+	case RuleMutationScope, RuleInvariantCheck:
+		return graphActionMutate, true
+	case RuleRestrictedFunctions:
+		return graphActionCall, true
+	case RuleCopyScope:
+		return graphActionCopy, true
+	default:
+		return "", false
+	}
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
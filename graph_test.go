@@ -0,0 +1,49 @@
+package goseal_test
+
+import (
+	"testing"
+
+	"github.com/jimmysharp/goseal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildGraph(t *testing.T) {
+	config, pkgs := loadTestdata(t, "basic")
+
+	g, err := goseal.BuildGraph(config, pkgs)
+	require.NoError(t, err)
+
+	const (
+		app    = "example.com/testproject/app"
+		domain = "example.com/testproject/domain"
+		user   = "example.com/testproject/domain.User"
+	)
+	require.Equal(t, []string{app, domain}, g.Packages)
+	require.Equal(t, []string{user}, g.Structs)
+	require.Equal(t, []goseal.GraphEdge{
+		{From: app, To: user, Action: "construct", Rule: goseal.RuleInitScope, Allowed: false, Count: 20},
+		{From: app, To: user, Action: "mutate", Rule: goseal.RuleMutationScope, Allowed: false, Count: 3},
+		{From: domain, To: user, Action: "construct", Rule: goseal.RuleFactoryNames, Allowed: false, Count: 1},
+		{From: domain, To: user, Action: "construct", Rule: goseal.RuleFactoryNames, Allowed: true, Count: 1},
+		{From: domain, To: user, Action: "mutate", Rule: goseal.RuleMutationScope, Allowed: false, Count: 2},
+		{From: domain, To: user, Action: "mutate", Rule: goseal.RuleMutationScope, Allowed: true, Count: 1},
+	}, g.Edges)
+}
+
+func TestGraphAction(t *testing.T) {
+	want := map[string]string{
+		"GS001": "construct",
+		"GS002": "construct",
+		"GS003": "mutate",
+		"GS004": "call",
+		"GS007": "copy",
+		"GS008": "construct",
+		"GS009": "construct",
+		"GS010": "mutate",
+	}
+	for _, rule := range goseal.Rules() {
+		action, ok := goseal.GraphAction(rule)
+		require.Equal(t, want[rule.Code], action, rule.Code)
+		require.Equal(t, want[rule.Code] != "", ok, rule.Code)
+	}
+}
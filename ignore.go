@@ -0,0 +1,110 @@
+package goseal
+
+import (
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+func (f *IgnoreFile) matches(relPath string) bool {
+	if f.Pattern != nil {
+		return f.Pattern.MatchString(relPath)
+	}
+	return matchGlob(f.Glob, relPath)
+}
+
+// ignoreFile returns the rules ignored in file and whether the whole file is ignored.
+func (c *goseal) ignoreFile(file *token.File) ([]string, bool) {
+	if len(c.config.IgnoreFiles) == 0 {
+		return nil, false
+	}
+	relPath := c.moduleRelativePath(file.Name())
+
+	var rules []string
+	for _, f := range c.config.IgnoreFiles {
+		if !f.matches(relPath) {
+			continue
+		}
+		if len(f.Rules) == 0 {
+			return nil, true
+		}
+		rules = append(rules, f.Rules...)
+	}
+	return rules, false
+}
+
+// moduleRelativePath returns filename relative to the root of its module, with
+// slashes as separators, so that patterns do not depend on where the module is
+// checked out. Files outside a module are returned with slashes only.
+func (c *goseal) moduleRelativePath(filename string) string {
+	dir := filepath.Dir(filename)
+	root, ok := c.moduleRoots.Load(dir)
+	if !ok {
+		root = findModuleRoot(dir)
+		c.moduleRoots.Store(dir, root)
+	}
+	if root != "" {
+		if rel, err := filepath.Rel(root.(string), filename); err == nil {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(filename)
+}
+
+// findModuleRoot returns the nearest directory containing a go.mod file,
+// starting at dir, or "" if there is none.
+func findModuleRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// matchGlob reports whether the slash-separated name matches pattern. Each
+// path element of pattern is matched with path.Match, except **, which
+// matches any number of path elements, including none.
+func matchGlob(pattern, name string) bool {
+	return matchGlobElems(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobElems(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := len(name); i >= 0; i-- {
+				if matchGlobElems(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// validateGlob reports an error if pattern is malformed.
+func validateGlob(pattern string) error {
+	for _, elem := range strings.Split(pattern, "/") {
+		if elem == "**" {
+			continue
+		}
+		if _, err := path.Match(elem, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Package analyzertest runs goseal analyzers in tests the way drivers do.
+package analyzertest
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/jimmysharp/goseal"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// Diagnostic is a diagnostic reported in a package given to Run.
+type Diagnostic struct {
+	Package  string // ID of the package, which differs between test variants
+	Position token.Position
+	Category string
+	Message  string
+}
+
+// Run loads the packages matching pattern in dir with their tests, as
+// analysistest does, and returns the diagnostics analyzers report in them.
+// Diagnostics of several analyzers, such as those of goseal.NewAnalyzers, are
+// checked to be reported by the analyzer of their rule.
+func Run(t *testing.T, dir, pattern string, analyzers ...*analysis.Analyzer) []Diagnostic {
+	t.Helper()
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: dir, Tests: true}, pattern)
+	require.NoError(t, err)
+	require.Zero(t, packages.PrintErrors(pkgs))
+
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	require.NoError(t, err)
+
+	var diagnostics []Diagnostic
+	for act := range graph.All() {
+		if !act.IsRoot {
+			continue
+		}
+		require.NoError(t, act.Err)
+		for _, d := range act.Diagnostics {
+			rule, ok := goseal.LookupRule(d.Category)
+			require.True(t, ok, "unknown category %q for %q", d.Category, d.Message)
+			if len(analyzers) > 1 {
+				require.Equal(t, rule.Analyzer, act.Analyzer.Name, "analyzer of %s", rule.Code)
+			}
+			diagnostics = append(diagnostics, Diagnostic{
+				Package:  act.Package.ID,
+				Position: act.Package.Fset.Position(d.Pos),
+				Category: d.Category,
+				Message:  d.Message,
+			})
+		}
+	}
+	return diagnostics
+}
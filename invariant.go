@@ -0,0 +1,200 @@
+package goseal
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/cfg"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// checkInvariants reports return paths of a pointer-receiver method of a
+// sealed struct that write fields of the receiver without calling a method
+// matching validate-methods on it afterwards.
+//
+// Methods that return an error, validation methods themselves and methods
+// annotated //goseal:novalidate are not checked.
+func (c *goseal) checkInvariants(fn *ast.FuncDecl, pass *analysis.Pass, stack []ast.Node, writes receiverWrites) {
+	if !c.config.RequireInvariantCheck || fn.Body == nil || fn.Recv == nil || len(fn.Recv.List[0].Names) == 0 {
+		return
+	}
+	if matchesAny(c.config.ValidateMethods, fn.Name.Name) || hasDirective(fn.Doc, "goseal:novalidate") || returnsError(fn, pass) {
+		return
+	}
+	recv := pass.TypesInfo.Defs[fn.Recv.List[0].Names[0]]
+	if recv == nil || !isPointer(recv.Type()) {
+		return
+	}
+	named := c.ownSealedStruct(recv.Type(), pass)
+	if named == nil {
+		return
+	}
+
+	check := &invariantCheck{c: c, pass: pass, recv: recv, writes: writes}
+	g := cfg.New(fn.Body, mayReturn(pass))
+
+	// Propagate whether fields were written since the last validation
+	// forward through the CFG until nothing changes
+	dirty := make([]bool, len(g.Blocks))
+	for changed := true; changed; {
+		changed = false
+		for _, b := range g.Blocks {
+			if !b.Live || !check.scan(b, dirty[b.Index]) {
+				continue
+			}
+			for _, succ := range b.Succs {
+				if !dirty[succ.Index] {
+					dirty[succ.Index] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	if check.deferred {
+		// A deferred validation runs on every return path
+		return
+	}
+	for _, b := range g.Blocks {
+		ret := b.Return()
+		if !b.Live || ret == nil || !check.scan(b, dirty[b.Index]) {
+			continue
+		}
+		c.report(
+			pass,
+			finding{rule: RuleInvariantCheck, node: ret, stack: stack, target: qualifiedName(named.Obj())},
+			"method %s returns after writing fields of sealed struct %s without re-checking its invariants (require-invariant-check); call a method matching validate-methods before returning, or return an error",
+			fn.Name.Name,
+			named.Obj().Name(),
+		)
+	}
+}
+
+// invariantCheck tracks writes to a method receiver and calls of validation
+// methods on it.
+type invariantCheck struct {
+	c        *goseal
+	pass     *analysis.Pass
+	recv     types.Object
+	writes   receiverWrites
+	deferred bool // A validation method is deferred
+}
+
+// scan returns whether fields of the receiver are written without a later
+// validation at the end of b, given whether they were at its start.
+func (ic *invariantCheck) scan(b *cfg.Block, dirty bool) bool {
+	var visit func(n ast.Node)
+	visit = func(n ast.Node) {
+		ast.Inspect(n, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.DeferStmt:
+				if ic.isValidation(n.Call) {
+					ic.deferred = true
+				}
+				return false
+			case *ast.AssignStmt:
+				// The right-hand side is evaluated before the assignment
+				for _, rhs := range n.Rhs {
+					visit(rhs)
+				}
+				for _, lhs := range n.Lhs {
+					if ic.isReceiverField(lhs) {
+						dirty = true
+					} else {
+						visit(lhs)
+					}
+				}
+				return false
+			case *ast.IncDecStmt:
+				if ic.isReceiverField(n.X) {
+					dirty = true
+				}
+				return false
+			case *ast.CallExpr:
+				visit(n.Fun)
+				for _, arg := range n.Args {
+					visit(arg)
+				}
+				if ic.isValidation(n) {
+					dirty = false
+				} else if ic.isMutatingFieldCall(n) {
+					dirty = true
+				}
+				return false
+			}
+			return true
+		})
+	}
+	for _, n := range b.Nodes {
+		visit(n)
+	}
+	return dirty
+}
+
+// isReceiverField reports whether expr denotes the value the receiver points
+// to or a part of it, e.g. *u, u.Age or u.Tags[0].
+func (ic *invariantCheck) isReceiverField(expr ast.Expr) bool {
+	part := false
+	for {
+		switch e := ast.Unparen(expr).(type) {
+		case *ast.Ident:
+			return part && ic.pass.TypesInfo.Uses[e] == ic.recv
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			if sel := ic.pass.TypesInfo.Selections[e]; sel == nil || sel.Kind() != types.FieldVal {
+				return false
+			}
+			expr = e.X
+		default:
+			return false
+		}
+		part = true
+	}
+}
+
+// isValidation reports whether call calls a method matching validate-methods on the receiver.
+func (ic *invariantCheck) isValidation(call *ast.CallExpr) bool {
+	fun, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || !matchesAny(ic.c.config.ValidateMethods, fun.Sel.Name) {
+		return false
+	}
+	x, ok := ast.Unparen(fun.X).(*ast.Ident)
+	return ok && ic.pass.TypesInfo.Uses[x] == ic.recv
+}
+
+// isMutatingFieldCall reports whether call calls a method that writes its
+// receiver on a non-pointer field of the receiver, e.g. u.Tags.Add("x").
+func (ic *invariantCheck) isMutatingFieldCall(call *ast.CallExpr) bool {
+	fun, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || !ic.isReceiverField(fun.X) || isPointer(ic.pass.TypesInfo.TypeOf(fun.X)) {
+		return false
+	}
+	sel := ic.pass.TypesInfo.Selections[fun]
+	if sel == nil || sel.Kind() != types.MethodVal {
+		return false
+	}
+	return ic.c.methodWritesReceiver(ic.writes, sel.Obj().(*types.Func))
+}
+
+// mayReturn returns a function reporting whether a call may return, for
+// building CFGs. Calls of panic, os.Exit and log.Fatal functions do not.
+func mayReturn(pass *analysis.Pass) func(*ast.CallExpr) bool {
+	return func(call *ast.CallExpr) bool {
+		switch fn := typeutil.Callee(pass.TypesInfo, call).(type) {
+		case *types.Builtin:
+			return fn.Name() != "panic"
+		case *types.Func:
+			switch qualifiedFuncName(fn) {
+			case "os.Exit", "log.Fatal", "log.Fatalf", "log.Fatalln", "log.Panic", "log.Panicf", "log.Panicln":
+				return false
+			}
+		}
+		return true
+	}
+}
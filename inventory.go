@@ -0,0 +1,210 @@
+package goseal
+
+import (
+	"cmp"
+	"fmt"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// Inventory lists the sealed structs of a set of packages together with
+// their factories, mutators and the violations against them.
+type Inventory struct {
+	Structs  []InventoryStruct   `json:"structs"`
+	Packages []PackageViolations `json:"packages"`
+}
+
+// InventoryStruct describes a sealed struct and the policy that applies to it.
+type InventoryStruct struct {
+	Name                string           `json:"name"`
+	Package             string           `json:"package"`
+	Position            string           `json:"position"`
+	InitScope           InitScope        `json:"init-scope"`
+	MutationScope       MutationScope    `json:"mutation-scope"`
+	InitAllowedFrom     []string         `json:"init-allowed-from"`     // Packages allowed to construct the struct, globally or for the struct
+	MutationAllowedFrom []string         `json:"mutation-allowed-from"` // Packages allowed to mutate the struct, globally or for the struct
+	Tests               *InventoryTests  `json:"tests,omitempty"`       // Scopes in test code, if the tests option is set
+	Factories           []string         `json:"factories"`             // Functions that construct the struct where allowed
+	Mutators            []string         `json:"mutators"`              // Receiver methods that assign its fields where allowed
+	Violations          []ViolationCount `json:"violations"`            // Violations against the struct by package and rule
+}
+
+// InventoryTests is the policy that applies to a sealed struct in test code.
+type InventoryTests struct {
+	InitScope     InitScope     `json:"init-scope"`
+	MutationScope MutationScope `json:"mutation-scope"`
+}
+
+// ViolationCount is the number of violations of a rule in a package.
+type ViolationCount struct {
+	Package string `json:"package"`
+	Code    string `json:"code"`
+	Count   int    `json:"count"`
+}
+
+// PackageViolations is the number of violations in a package by rule code.
+type PackageViolations struct {
+	Package    string         `json:"package"`
+	Violations map[string]int `json:"violations"`
+	Total      int            `json:"total"`
+}
+
+// BuildInventory analyzes pkgs with config and returns the inventory of the
+// sealed structs declared in them. Test variants of packages are analyzed for
+// violations, but only structs declared in non-test files are listed.
+func BuildInventory(config *Config, pkgs []*packages.Package) (*Inventory, error) {
+	decisions, err := collectDecisions(config, pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &goseal{config: config}
+	inv := &Inventory{
+		Structs:  []InventoryStruct{},
+		Packages: []PackageViolations{},
+	}
+
+	byStruct := make(map[string][]Decision)
+	for _, d := range decisions {
+		byStruct[d.Struct] = append(byStruct[d.Struct], d)
+	}
+
+	for _, pkg := range pkgs {
+		// Skip test variants; their non-test files are in the plain package
+		if pkg.ID != pkg.PkgPath || pkg.Types == nil || !c.isTargetPackage(pkg.PkgPath) {
+			continue
+		}
+
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || obj.IsAlias() {
+				continue
+			}
+			if _, ok := obj.Type().Underlying().(*types.Struct); !ok || c.isExcludedStruct(name) {
+				continue
+			}
+
+			inv.Structs = append(inv.Structs, c.inventoryStruct(pkg, obj, byStruct[qualifiedName(obj)]))
+		}
+	}
+	slices.SortFunc(inv.Structs, func(x, y InventoryStruct) int {
+		return cmp.Or(cmp.Compare(x.Package, y.Package), cmp.Compare(x.Name, y.Name))
+	})
+
+	byPackage := make(map[string]*PackageViolations)
+	for _, d := range decisions {
+		if d.Allowed {
+			continue
+		}
+		pv := byPackage[d.Package]
+		if pv == nil {
+			pv = &PackageViolations{Package: d.Package, Violations: make(map[string]int)}
+			byPackage[d.Package] = pv
+		}
+		pv.Violations[d.Rule.Code]++
+		pv.Total++
+	}
+	for _, path := range slices.Sorted(maps.Keys(byPackage)) {
+		inv.Packages = append(inv.Packages, *byPackage[path])
+	}
+
+	return inv, nil
+}
+
+func (c *goseal) inventoryStruct(pkg *packages.Package, obj *types.TypeName, decisions []Decision) InventoryStruct {
+	factories := make(map[string]bool)
+	mutators := make(map[string]bool)
+	violations := make(map[ViolationCount]int)
+
+	for _, d := range decisions {
+		switch {
+		case !d.Allowed:
+			violations[ViolationCount{Package: d.Package, Code: d.Rule.Code}]++
+		case d.Rule == RuleMutationScope:
+			if receiverTypeName(d.Function) == obj.Name() && d.Package == pkg.PkgPath {
+				mutators[d.Function] = true
+			}
+		case d.Rule != RuleInitScope && d.Rule != RuleFactoryNames:
+			// Other allowed decisions, such as copies, do not construct the struct
+		case !strings.HasPrefix(d.Function, "var ") && d.Function != "":
+			if d.Package == pkg.PkgPath {
+				factories[d.Function] = true
+			} else {
+				factories[d.Package+"."+d.Function] = true
+			}
+		}
+	}
+
+	counts := []ViolationCount{}
+	for vc, n := range violations {
+		vc.Count = n
+		counts = append(counts, vc)
+	}
+	slices.SortFunc(counts, func(x, y ViolationCount) int {
+		return cmp.Or(cmp.Compare(x.Package, y.Package), cmp.Compare(x.Code, y.Code))
+	})
+
+	initScope, _ := c.initScope(location{})
+	mutationScope, _ := c.mutationScope(location{})
+	s := InventoryStruct{
+		Name:                obj.Name(),
+		Package:             pkg.PkgPath,
+		Position:            pkg.Fset.Position(obj.Pos()).String(),
+		InitScope:           initScope,
+		MutationScope:       mutationScope,
+		InitAllowedFrom:     patternStrings(c.initAllowedFrom(obj)),
+		MutationAllowedFrom: patternStrings(c.mutationAllowedFrom(obj)),
+		Factories:           sortedNames(factories),
+		Mutators:            sortedNames(mutators),
+		Violations:          counts,
+	}
+	if c.config.Tests != nil {
+		testInitScope, _ := c.initScope(location{test: true})
+		testMutationScope, _ := c.mutationScope(location{test: true})
+		s.Tests = &InventoryTests{InitScope: testInitScope, MutationScope: testMutationScope}
+	}
+	return s
+}
+
+// sortedNames returns the names in set, sorted, and never nil so that they
+// are encoded as a JSON array.
+func sortedNames(set map[string]bool) []string {
+	return append([]string{}, slices.Sorted(maps.Keys(set))...)
+}
+
+// collectDecisions runs the analyzer over pkgs and returns all of its decisions.
+func collectDecisions(config *Config, pkgs []*packages.Package) ([]Decision, error) {
+	recorder := &Recorder{}
+	a := NewAnalyzer(config, WithRecorder(recorder))
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{a}, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			return nil, fmt.Errorf("%s: %w", act.Package.PkgPath, act.Err)
+		}
+	}
+
+	return recorder.Decisions(), nil
+}
+
+// receiverTypeName returns the receiver type name of a method key such as
+// "(*User).Rename" or "(Box[T]).Get", or "" for plain functions.
+func receiverTypeName(funcKey string) string {
+	recv, _, ok := strings.Cut(funcKey, ").")
+	if !ok || !strings.HasPrefix(recv, "(") {
+		return ""
+	}
+	recv = strings.TrimPrefix(strings.TrimPrefix(recv, "("), "*")
+	name, _, _ := strings.Cut(recv, "[")
+	return name
+}
@@ -0,0 +1,103 @@
+package goseal_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jimmysharp/goseal"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/packages"
+)
+
+func loadTestdata(t *testing.T, name string) (*goseal.Config, []*packages.Package) {
+	t.Helper()
+
+	testdataDir := filepath.Join(analysistest.TestData(), name)
+	config, err := goseal.ParseConfig(filepath.Join(testdataDir, ".goseal.yml"))
+	require.NoError(t, err)
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:  packages.LoadAllSyntax,
+		Dir:   testdataDir,
+		Tests: true,
+	}, "example.com/testproject/...")
+	require.NoError(t, err)
+	require.Zero(t, packages.PrintErrors(pkgs))
+
+	return config, pkgs
+}
+
+func TestBuildInventory(t *testing.T) {
+	config, pkgs := loadTestdata(t, "basic")
+
+	inv, err := goseal.BuildInventory(config, pkgs)
+	require.NoError(t, err)
+
+	require.Len(t, inv.Structs, 1)
+	user := inv.Structs[0]
+	require.Equal(t, "User", user.Name)
+	require.Equal(t, "example.com/testproject/domain", user.Package)
+	require.Equal(t, goseal.InitScopeSamePackage, user.InitScope)
+	require.Equal(t, goseal.MutationScopeReceiver, user.MutationScope)
+	require.Nil(t, user.Tests)
+	require.Equal(t, []string{"NewUser"}, user.Factories)
+	require.Equal(t, []string{"(*User).UpdateName"}, user.Mutators)
+	require.Equal(t, []goseal.ViolationCount{
+		{Package: "example.com/testproject/app", Code: "GS001", Count: 20},
+		{Package: "example.com/testproject/app", Code: "GS003", Count: 3},
+		{Package: "example.com/testproject/domain", Code: "GS002", Count: 1},
+		{Package: "example.com/testproject/domain", Code: "GS003", Count: 2},
+	}, user.Violations)
+
+	require.Equal(t, []goseal.PackageViolations{
+		{Package: "example.com/testproject/app", Violations: map[string]int{"GS001": 20, "GS003": 3}, Total: 23},
+		{Package: "example.com/testproject/domain", Violations: map[string]int{"GS002": 1, "GS003": 2}, Total: 3},
+	}, inv.Packages)
+}
+
+func TestBuildInventory_StructPolicy(t *testing.T) {
+	config, pkgs := loadTestdata(t, "inventory")
+
+	inv, err := goseal.BuildInventory(config, pkgs)
+	require.NoError(t, err)
+
+	// Each struct lists the settings that apply to it, including its
+	// per-struct settings and the scopes of test code
+	tests := &goseal.InventoryTests{InitScope: goseal.InitScopeAny, MutationScope: goseal.MutationScopeReceiver}
+	require.Len(t, inv.Structs, 2)
+
+	order := inv.Structs[0]
+	require.Equal(t, "Order", order.Name)
+	require.Equal(t, goseal.InitScopeSamePackage, order.InitScope)
+	require.Equal(t, goseal.MutationScopeReceiver, order.MutationScope)
+	require.Equal(t, []string{"example\\.com/testproject/domain/testfixtures"}, order.InitAllowedFrom)
+	require.Empty(t, order.MutationAllowedFrom)
+	require.Equal(t, tests, order.Tests)
+	require.Equal(t, []string{"NewOrder"}, order.Factories)
+	require.Equal(t, []goseal.ViolationCount{
+		{Package: "example.com/testproject/infra", Code: "GS001", Count: 1},
+	}, order.Violations)
+
+	user := inv.Structs[1]
+	require.Equal(t, "User", user.Name)
+	require.Equal(t, goseal.InitScopeSamePackage, user.InitScope)
+	require.Equal(t, goseal.MutationScopeReceiver, user.MutationScope)
+	require.Equal(t, []string{"example\\.com/testproject/domain/testfixtures", "example\\.com/testproject/infra"}, user.InitAllowedFrom)
+	require.Equal(t, []string{"example\\.com/testproject/infra"}, user.MutationAllowedFrom)
+	require.Equal(t, tests, user.Tests)
+	require.Equal(t, []string{"NewUser", "example.com/testproject/infra.ToUser"}, user.Factories)
+	require.Empty(t, user.Violations)
+}
+
+func TestBuildInventory_AllowedCopies(t *testing.T) {
+	config, pkgs := loadTestdata(t, "config/copy_scope")
+
+	inv, err := goseal.BuildInventory(config, pkgs)
+	require.NoError(t, err)
+
+	// Clone only copies the struct, which copy-scope allows; it is not a factory
+	require.Len(t, inv.Structs, 1)
+	require.Equal(t, []string{"NewUser"}, inv.Structs[0].Factories)
+	require.Equal(t, []string{}, inv.Structs[0].Mutators)
+}
@@ -0,0 +1,58 @@
+package goseal
+
+import (
+	"go/types"
+	"sync"
+)
+
+// matchCache memoises the decisions of the target-packages and
+// exclude-structs matchers, which would otherwise run every regexp for each
+// literal, assignment and selector. Drivers run passes concurrently, so the
+// maps are safe for concurrent use.
+type matchCache struct {
+	packages sync.Map // Package path -> bool, whether the package is targeted
+	structs  sync.Map // *types.TypeName -> bool, whether the type is sealed
+}
+
+func (c *goseal) isTargetPackage(pkgPath string) bool {
+	if v, ok := c.matches.packages.Load(pkgPath); ok {
+		return v.(bool)
+	}
+	target := c.matchTargetPackage(pkgPath)
+	c.matches.packages.Store(pkgPath, target)
+	return target
+}
+
+func (c *goseal) matchTargetPackage(pkgPath string) bool {
+	// If no target-packages are specified, target all packages
+	if len(c.config.TargetPackages) == 0 {
+		return true
+	}
+	// If target-packages are specified, only target matching packages
+	for _, pattern := range c.config.TargetPackages {
+		if pattern.MatchString(pkgPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *goseal) isExcludedStruct(structName string) bool {
+	for _, pattern := range c.config.ExcludeStructs {
+		if pattern.MatchString(structName) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSealedType reports whether obj is declared in a target package and not
+// excluded. It does not check that obj is a struct.
+func (c *goseal) isSealedType(obj *types.TypeName) bool {
+	if v, ok := c.matches.structs.Load(obj); ok {
+		return v.(bool)
+	}
+	sealed := obj.Pkg() != nil && c.isTargetPackage(obj.Pkg().Path()) && !c.isExcludedStruct(obj.Name())
+	c.matches.structs.Store(obj, sealed)
+	return sealed
+}
@@ -0,0 +1,106 @@
+package goseal
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// isInOwnPackageInit reports whether the top of stack is in a package-level
+// variable declaration or an init function of structPkg, where
+// allow-package-vars and allow-init-funcs permit construction regardless of
+// factory-names.
+func (c *goseal) isInOwnPackageInit(stack []ast.Node, loc location, structPkg string) bool {
+	if loc.pkg != structPkg {
+		return false
+	}
+
+	fn := c.getEnclosingFunc(stack)
+	if fn == nil {
+		return c.config.AllowPackageVars && isInPackageVar(stack)
+	}
+	return c.config.AllowInitFuncs && fn.Recv == nil && fn.Name.Name == "init"
+}
+
+// isInPackageVar reports whether the top of stack is evaluated when a
+// package-level var declaration is initialized, i.e. not in a function
+// literal of it unless the literal is invoked immediately.
+func isInPackageVar(stack []ast.Node) bool {
+	// Package-level declarations are at [*ast.File, *ast.GenDecl, ...]
+	if len(stack) < 2 {
+		return false
+	}
+	if decl, ok := stack[1].(*ast.GenDecl); !ok || decl.Tok != token.VAR {
+		return false
+	}
+	for i := 2; i < len(stack); i++ {
+		if lit, ok := stack[i].(*ast.FuncLit); ok && !isInvokedImmediately(stack[:i], lit) {
+			return false
+		}
+	}
+	return true
+}
+
+// isInvokedImmediately reports whether lit, whose ancestors are stack, is
+// called where it is declared, as in func() T { ... }(), and not in a go
+// statement.
+func isInvokedImmediately(stack []ast.Node, lit *ast.FuncLit) bool {
+	i := len(stack) - 1
+	for i >= 0 {
+		if _, ok := stack[i].(*ast.ParenExpr); !ok {
+			break
+		}
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	call, ok := stack[i].(*ast.CallExpr)
+	if !ok || ast.Unparen(call.Fun) != lit {
+		return false
+	}
+	if i > 0 {
+		if _, ok := stack[i-1].(*ast.GoStmt); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// checkExportedVars reports exported package-level variables holding a
+// sealed struct or a pointer to one, which any package can reassign.
+func (c *goseal) checkExportedVars(spec *ast.ValueSpec, pass *analysis.Pass, stack []ast.Node) {
+	if !c.config.ReportExportedVars {
+		return
+	}
+	// Package-level declarations are at [*ast.File, *ast.GenDecl, *ast.ValueSpec]
+	if len(stack) != 3 {
+		return
+	}
+	if decl, ok := stack[1].(*ast.GenDecl); !ok || decl.Tok != token.VAR {
+		return
+	}
+
+	for _, name := range spec.Names {
+		if !name.IsExported() {
+			continue
+		}
+		obj, ok := pass.TypesInfo.Defs[name].(*types.Var)
+		if !ok {
+			continue
+		}
+		named := c.sealedStruct(obj.Type())
+		if named == nil {
+			continue
+		}
+		c.report(
+			pass,
+			finding{rule: RuleExportedVars, node: name, stack: stack, target: qualifiedName(named.Obj())},
+			"exported package-level variable %s holds sealed struct %s and can be reassigned by any package (report-exported-vars); unexport it and provide a function returning it",
+			name.Name,
+			named.Obj().Name(),
+		)
+	}
+}
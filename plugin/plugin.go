@@ -1,11 +1,21 @@
 package plugin
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-yaml"
 	"github.com/golangci/plugin-module-register/register"
 	"github.com/jimmysharp/goseal"
 	"golang.org/x/tools/go/analysis"
 )
 
+// configFileKey is the setting naming a .goseal.yml file whose settings are
+// merged with the inline ones.
+const configFileKey = "config-file"
+
 func init() {
 	register.Plugin("goseal", New)
 }
@@ -14,7 +24,32 @@ type Plugin struct {
 	config *goseal.Config
 }
 
+// New decodes the plugin settings. If they set config-file, the settings of
+// that file are used, with each top-level key set inline replacing the key
+// of the file entirely (lists and objects such as tests are not merged).
 func New(settings any) (register.LinterPlugin, error) {
+	inline, err := register.DecodeSettings[map[string]any](settings)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := inline[configFileKey]; ok {
+		path, ok := v.(string)
+		if !ok || path == "" {
+			return nil, fmt.Errorf("invalid %s: %v (must be a file path)", configFileKey, v)
+		}
+		fileSettings, err := loadConfigFile(resolveConfigFile(path))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", configFileKey, err)
+		}
+
+		delete(inline, configFileKey)
+		for key, value := range inline {
+			fileSettings[key] = value
+		}
+		settings = fileSettings
+	}
+
 	s, err := register.DecodeSettings[goseal.Config](settings)
 	if err != nil {
 		return nil, err
@@ -23,10 +58,92 @@ func New(settings any) (register.LinterPlugin, error) {
 	return &Plugin{config: &s}, nil
 }
 
-func (p *Plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
-	analyzer := goseal.NewAnalyzer(p.config)
+// loadConfigFile checks the config file at path with goseal.ParseConfig and
+// returns its settings as written, so that options the file leaves unset
+// (e.g. tests.init-scope) default to the merged settings.
+func loadConfigFile(path string) (map[string]any, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	if _, err := goseal.ParseConfig(path); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
 
-	return []*analysis.Analyzer{analyzer}, nil
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var settings map[string]any
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if settings == nil {
+		settings = make(map[string]any)
+	}
+	return settings, nil
+}
+
+// resolveConfigFile resolves a relative path against the directory of the
+// golangci-lint configuration.
+func resolveConfigFile(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(golangciConfigDir(os.Args[1:]), path)
+}
+
+// golangciConfigFiles are the names golangci-lint looks for, in its order.
+var golangciConfigFiles = []string{".golangci.yml", ".golangci.yaml", ".golangci.toml", ".golangci.json"}
+
+// golangciConfigDir returns the directory of the golangci-lint configuration:
+// the file given with -c or --config in args, or else the first config file
+// found in the working directory or its parents, as golangci-lint does.
+// Without either, it returns the working directory.
+func golangciConfigDir(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || (name != "c" && name != "config") {
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				break
+			}
+			value = args[i+1]
+		}
+		return filepath.Dir(absPath(value))
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+	for dir := wd; ; {
+		for _, name := range golangciConfigFiles {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return dir
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return wd
+		}
+		dir = parent
+	}
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+func (p *Plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	return goseal.NewAnalyzers(p.config), nil
 }
 
 func (p *Plugin) GetLoadMode() string {
@@ -1,13 +1,19 @@
 package plugin_test
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"testing"
 
 	"github.com/golangci/plugin-module-register/register"
+	"github.com/jimmysharp/goseal"
+	"github.com/jimmysharp/goseal/internal/analyzertest"
 	_ "github.com/jimmysharp/goseal/plugin"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -29,13 +35,132 @@ func TestModulePlugin_Basic(t *testing.T) {
 
 	analyzers, err := p.BuildAnalyzers()
 	require.NoError(t, err)
-	require.Len(t, analyzers, 1)
-	require.Equal(t, "goseal", analyzers[0].Name)
+	require.NoError(t, analysis.Validate(analyzers))
+
+	var names []string
+	for _, a := range analyzers {
+		names = append(names, a.Name)
+	}
+	require.Equal(t, []string{"goseal_init", "goseal_mutation", "goseal_restricted", "goseal_aliasing", "goseal_copy", "goseal"}, names)
+
+	// The combined analyzer reports exactly the expected diagnostics, and the
+	// split analyzers of the plugin report the same
+	config, err := register.DecodeSettings[goseal.Config](settings)
+	require.NoError(t, err)
+	combined := goseal.NewAnalyzer(&config)
+	analysistest.Run(t, testdataDir(t), combined, "example.com/testproject/...")
+
+	require.Equal(t, diagnostics(t, combined), diagnostics(t, analyzers...))
+}
+
+func TestModulePlugin_ConfigFile(t *testing.T) {
+	newPlugin, err := register.GetPlugin("goseal")
+	require.NoError(t, err)
+
+	// The config file is resolved relative to the nearest golangci-lint
+	// config, not the working directory
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".golangci.yml"), "version: \"2\"\n")
+	data, err := os.ReadFile(filepath.Join(testdataDir(t), ".goseal.yml"))
+	require.NoError(t, err)
+	writeFile(t, filepath.Join(root, "config", "goseal.yml"), string(data)+"\ndisabled-rules:\n  - GS002\n")
+	require.NoError(t, os.Mkdir(filepath.Join(root, "sub"), 0o755))
+	t.Chdir(filepath.Join(root, "sub"))
+
+	// Inline settings replace the keys of the file
+	p, err := newPlugin(map[string]any{
+		"config-file":    "config/goseal.yml",
+		"disabled-rules": []string{"GS003"},
+	})
+	require.NoError(t, err)
+
+	analyzers, err := p.BuildAnalyzers()
+	require.NoError(t, err)
+
+	config, err := goseal.ParseFromYAML(data)
+	require.NoError(t, err)
+	config.DisabledRules = []string{"GS003"}
+	require.Equal(t, diagnostics(t, goseal.NewAnalyzer(config)), diagnostics(t, analyzers...))
+}
+
+func TestModulePlugin_ConfigFileErrors(t *testing.T) {
+	newPlugin, err := register.GetPlugin("goseal")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "valid.yml"), "init-scope: any\n")
+	writeFile(t, filepath.Join(dir, "invalid.yml"), "init-scope: bogus\n")
+
+	tests := []struct {
+		name     string
+		settings map[string]any
+		wantErr  string
+	}{
+		{
+			name:     "not a path",
+			settings: map[string]any{"config-file": 1},
+			wantErr:  "invalid config-file: 1 (must be a file path)",
+		},
+		{
+			name:     "missing file",
+			settings: map[string]any{"config-file": filepath.Join(dir, "missing.yml")},
+			wantErr:  "invalid config-file: stat " + filepath.Join(dir, "missing.yml"),
+		},
+		{
+			name:     "invalid file",
+			settings: map[string]any{"config-file": filepath.Join(dir, "invalid.yml")},
+			wantErr:  "invalid config-file: " + filepath.Join(dir, "invalid.yml") + ": failed to parse config data: invalid init-scope: bogus",
+		},
+		{
+			name: "invalid inline setting",
+			settings: map[string]any{
+				"config-file":    filepath.Join(dir, "valid.yml"),
+				"mutation-scope": "bogus",
+			},
+			wantErr: "invalid mutation-scope: bogus",
+		},
+		{
+			name: "unknown inline setting",
+			settings: map[string]any{
+				"config-file": filepath.Join(dir, "valid.yml"),
+				"init-scop":   "any",
+			},
+			wantErr: "unknown config key \"init-scop\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newPlugin(tt.settings)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func testdataDir(t *testing.T) string {
+	t.Helper()
 
 	_, thisFile, _, ok := runtime.Caller(0)
 	require.True(t, ok)
 	repoRoot := filepath.Clean(filepath.Join(filepath.Dir(thisFile), ".."))
+	return filepath.Join(repoRoot, "testdata", "basic")
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
 
-	testdataDir := filepath.Join(repoRoot, "testdata", "basic")
-	analysistest.Run(t, testdataDir, analyzers[0], "example.com/testproject/...")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+}
+
+// diagnostics runs analyzers on testdata/basic and returns their diagnostics
+// in a stable order, including duplicates.
+func diagnostics(t *testing.T, analyzers ...*analysis.Analyzer) []string {
+	t.Helper()
+
+	var got []string
+	for _, d := range analyzertest.Run(t, testdataDir(t), "example.com/testproject/...", analyzers...) {
+		got = append(got, fmt.Sprintf("%s: %s: %s: %s", d.Package, d.Position, d.Category, d.Message))
+	}
+	slices.Sort(got)
+	return got
 }
@@ -0,0 +1,234 @@
+package goseal
+
+import (
+	"go/ast"
+	"go/build"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// readOnlyMethodFact is exported for methods with a pointer receiver that
+// never write their receiver. Methods without the fact are assumed to write it.
+type readOnlyMethodFact struct{}
+
+func (*readOnlyMethodFact) AFact() {}
+
+func (*readOnlyMethodFact) String() string { return "readOnlyMethod" }
+
+// receiverWrites records whether the pointer-receiver methods declared or
+// used in a package write their receiver.
+type receiverWrites map[*types.Func]bool
+
+// analyzeReceiverWrites determines which pointer-receiver methods of the
+// package write their receiver, and exports facts for those that do not.
+//
+// A method writes its receiver if it assigns to the receiver or its fields
+// (including elements of their arrays, slices and maps), takes their address,
+// lets the receiver pointer escape, or calls a method that writes them.
+func (c *goseal) analyzeReceiverWrites(pass *analysis.Pass) receiverWrites {
+	writes := make(receiverWrites)
+	calls := make(map[*types.Func][]*types.Func) // Methods of the package called on the receiver
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Body == nil {
+				continue
+			}
+			obj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func)
+			if !ok || !hasPointerReceiver(obj) {
+				continue
+			}
+			writes[obj], calls[obj] = c.writesReceiver(pass, fn)
+		}
+	}
+
+	// Propagate writes through calls between methods of the package;
+	// methods that only call each other remain read-only.
+	for changed := true; changed; {
+		changed = false
+		for fn, callees := range calls {
+			if writes[fn] {
+				continue
+			}
+			for _, callee := range callees {
+				if w, ok := writes[callee]; w || !ok {
+					writes[fn] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	for fn, w := range writes {
+		if !w {
+			pass.ExportObjectFact(fn, &readOnlyMethodFact{})
+		}
+	}
+	return writes
+}
+
+// writesReceiver reports whether the body of fn writes its receiver directly,
+// and returns the methods of the package it calls on the receiver.
+func (c *goseal) writesReceiver(pass *analysis.Pass, fn *ast.FuncDecl) (bool, []*types.Func) {
+	names := fn.Recv.List[0].Names
+	if len(names) == 0 {
+		return false, nil
+	}
+	recv := pass.TypesInfo.Defs[names[0]]
+	if recv == nil {
+		return false, nil
+	}
+
+	writes := false
+	var calls []*types.Func
+	ast.PreorderStack(fn.Body, nil, func(n ast.Node, stack []ast.Node) bool {
+		if writes {
+			return false
+		}
+		ident, ok := n.(*ast.Ident)
+		if !ok || pass.TypesInfo.Uses[ident] != recv {
+			return true
+		}
+
+		// Find the outermost expression denoting the receiver or part of it
+		var e ast.Expr = ident
+		i := len(stack) - 1
+	ascend:
+		for ; i >= 0; i-- {
+			switch p := stack[i].(type) {
+			case *ast.ParenExpr, *ast.StarExpr:
+				e = p.(ast.Expr)
+			case *ast.IndexExpr:
+				if p.X != e {
+					break ascend
+				}
+				e = p
+			case *ast.SelectorExpr:
+				if sel := pass.TypesInfo.Selections[p]; p.X != e || sel == nil || sel.Kind() != types.FieldVal {
+					break ascend
+				}
+				e = p
+			default:
+				break ascend
+			}
+		}
+		if i < 0 {
+			return true
+		}
+
+		switch p := stack[i].(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range p.Lhs {
+				if lhs == e {
+					writes = true
+				}
+			}
+			if !writes && isPointer(pass.TypesInfo.TypeOf(e)) {
+				// The receiver pointer is copied
+				writes = true
+			}
+		case *ast.IncDecStmt:
+			writes = true
+		case *ast.RangeStmt:
+			writes = p.Key == e || p.Value == e
+		case *ast.UnaryExpr:
+			writes = p.Op == token.AND
+		case *ast.SelectorExpr:
+			// A method called on the receiver or one of its fields
+			callee, ok := pass.TypesInfo.Uses[p.Sel].(*types.Func)
+			if !ok || !hasPointerReceiver(callee) || c.isReadOnlyMethod(callee) {
+				return true
+			}
+			callee = callee.Origin()
+			if callee.Pkg() == pass.Pkg {
+				calls = append(calls, callee)
+			} else {
+				writes = !pass.ImportObjectFact(callee, new(readOnlyMethodFact))
+			}
+		case *ast.BinaryExpr:
+			// Comparisons do not write
+		default:
+			// The receiver pointer escapes, e.g. as an argument or a result
+			writes = isPointer(pass.TypesInfo.TypeOf(e))
+		}
+		return true
+	})
+	return writes, calls
+}
+
+// methodWritesReceiver reports whether calling fn may write its receiver.
+// Methods missing from writes are assumed to write it.
+func (c *goseal) methodWritesReceiver(writes receiverWrites, fn *types.Func) bool {
+	if !hasPointerReceiver(fn) || c.isReadOnlyMethod(fn) {
+		return false
+	}
+	w, ok := writes[fn.Origin()]
+	return w || !ok
+}
+
+func (c *goseal) isReadOnlyMethod(fn *types.Func) bool {
+	return fn.Pkg() != nil && matchesAny(c.config.ReadOnlyMethods, qualifiedFuncName(fn))
+}
+
+// checkMethodCall checks calls of pointer-receiver methods on fields of sealed
+// structs, e.g. user.Tags.Add("x"), which take the address of the field.
+func (c *goseal) checkMethodCall(call *ast.CallExpr, pass *analysis.Pass, stack []ast.Node, writes receiverWrites) {
+	fun, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	sel := pass.TypesInfo.Selections[fun]
+	if sel == nil || sel.Kind() != types.MethodVal {
+		return
+	}
+	field, ok := ast.Unparen(fun.X).(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	if fs := pass.TypesInfo.Selections[field]; fs == nil || fs.Kind() != types.FieldVal {
+		return
+	}
+	// Methods called through pointer fields do not write the field itself
+	if isPointer(pass.TypesInfo.TypeOf(field)) {
+		return
+	}
+
+	method := sel.Obj().(*types.Func)
+	if !c.methodWritesReceiver(writes, method) {
+		return
+	}
+	c.checkFieldMutation(pass, call, field, stack, "call to mutating method "+method.Name()+" on")
+}
+
+func hasPointerReceiver(fn *types.Func) bool {
+	recv := fn.Signature().Recv()
+	return recv != nil && isPointer(recv.Type())
+}
+
+func isPointer(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	_, ok := typ.Underlying().(*types.Pointer)
+	return ok
+}
+
+// isDependency reports whether pass analyzes a dependency outside the main
+// module, such as the standard library. Drivers analyze dependencies only to
+// compute facts and discard their diagnostics.
+func isDependency(pass *analysis.Pass) bool {
+	if pass.Module != nil && pass.Module.Path != "" {
+		return pass.Module.Version != ""
+	}
+	if len(pass.Files) == 0 {
+		return false
+	}
+	goroot := filepath.Join(build.Default.GOROOT, "src") + string(filepath.Separator)
+	return strings.HasPrefix(pass.Fset.File(pass.Files[0].Pos()).Name(), goroot)
+}
@@ -0,0 +1,136 @@
+package goseal
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+func (c *goseal) checkCallExpr(call *ast.CallExpr, pass *analysis.Pass, stack []ast.Node) {
+	if len(c.config.RestrictedFunctions) == 0 {
+		return
+	}
+
+	fn := typeutil.StaticCallee(pass.TypesInfo, call)
+	if fn == nil || fn.Pkg() == nil {
+		return
+	}
+	c.checkRestrictedUse(fn, call, pass, stack, "call to")
+}
+
+// checkFuncValue checks a restricted function used as a value rather than
+// called, such as f := domain.ReconstituteUser, a callback argument or the
+// method value u.Restore. Calls are checked by checkCallExpr.
+func (c *goseal) checkFuncValue(id *ast.Ident, pass *analysis.Pass, stack []ast.Node) {
+	if len(c.config.RestrictedFunctions) == 0 {
+		return
+	}
+
+	fn, ok := pass.TypesInfo.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return
+	}
+	// Interface methods have no static callee, as in checkCallExpr
+	if recv := fn.Signature().Recv(); recv != nil && types.IsInterface(recv.Type()) {
+		return
+	}
+
+	// The function expression is id, or the selector of which id is the
+	// name (e.g. domain.ReconstituteUser), possibly instantiated
+	var expr ast.Expr = id
+	i := len(stack) - 2 // stack ends with id
+	if i >= 0 {
+		if sel, ok := stack[i].(*ast.SelectorExpr); ok && sel.Sel == id {
+			expr = sel
+			i--
+		}
+	}
+	for ; i >= 0; i-- {
+		switch parent := stack[i].(type) {
+		case *ast.ParenExpr, *ast.IndexExpr, *ast.IndexListExpr:
+			continue
+		case *ast.CallExpr:
+			if fun := ast.Unparen(parent.Fun); fun == expr || isInstantiationOf(fun, expr) {
+				return
+			}
+		}
+		break
+	}
+
+	c.checkRestrictedUse(fn, expr, pass, stack, "use as a value of")
+}
+
+// isInstantiationOf reports whether e instantiates the generic function fun,
+// as in F[int].
+func isInstantiationOf(e, fun ast.Expr) bool {
+	switch e := e.(type) {
+	case *ast.IndexExpr:
+		return ast.Unparen(e.X) == fun
+	case *ast.IndexListExpr:
+		return ast.Unparen(e.X) == fun
+	}
+	return false
+}
+
+// checkRestrictedUse reports node, a call or other use of fn, if fn is
+// restricted and not allowed at node.
+func (c *goseal) checkRestrictedUse(fn *types.Func, node ast.Node, pass *analysis.Pass, stack []ast.Node, use string) {
+	fn = fn.Origin()
+
+	name := qualifiedFuncName(fn)
+	restricted := c.restrictedFunction(name)
+	if restricted == nil {
+		return
+	}
+
+	loc := c.locate(pass, node)
+	f := finding{rule: RuleRestrictedFunctions, node: node, stack: stack, target: name}
+
+	if !matchesAny(restricted.AllowedFrom, loc.pkg) && !c.isInitAllowedByScope(restricted.Scope, loc, fn.Pkg().Path()) {
+		c.report(
+			pass,
+			f,
+			"%s restricted function %s is not allowed %s (restricted-functions: %s)",
+			use,
+			fn.Pkg().Name()+"."+funcName(fn),
+			initScopeDescription(restricted.Scope),
+			restricted.Scope,
+		)
+		return
+	}
+
+	c.record(pass, f, true)
+}
+
+// restrictedFunction returns the first restricted-functions entry matching name, if any.
+func (c *goseal) restrictedFunction(name string) *RestrictedFunction {
+	for _, rf := range c.config.RestrictedFunctions {
+		if rf.Pattern.MatchString(name) {
+			return rf
+		}
+	}
+	return nil
+}
+
+// qualifiedFuncName returns the name of fn matched by restricted-functions and read-only-methods,
+// e.g. "example.com/domain.ReconstituteUser" or "example.com/domain.User.Restore".
+func qualifiedFuncName(fn *types.Func) string {
+	return fn.Pkg().Path() + "." + funcName(fn)
+}
+
+// funcName returns the name of fn, qualified by its receiver type for methods (e.g. "User.Restore").
+func funcName(fn *types.Func) string {
+	name := fn.Name()
+	if recv := fn.Signature().Recv(); recv != nil {
+		typ := recv.Type()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		if named, ok := typ.(*types.Named); ok {
+			name = named.Obj().Name() + "." + name
+		}
+	}
+	return name
+}
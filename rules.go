@@ -0,0 +1,130 @@
+package goseal
+
+import (
+	"strings"
+)
+
+const rulesDocURL = "https://github.com/jimmysharp/goseal/blob/master/docs/rules.md"
+
+// Rule describes a kind of diagnostic reported by goseal.
+// The code is stable and is set as the Category of every diagnostic.
+type Rule struct {
+	Code     string // Stable diagnostic code, e.g. "GS001"
+	Option   string // Config option that controls the rule
+	Analyzer string // Name of the analyzer reporting the rule, or "" for goseal advise
+	Doc      string // One-line description of the rule
+}
+
+var (
+	RuleBaselineFixed = Rule{
+		Code:     "GS000",
+		Option:   "baseline",
+		Analyzer: "goseal",
+		Doc:      "Baseline entries must still match an existing violation",
+	}
+	RuleInitScope = Rule{
+		Code:     "GS001",
+		Option:   "init-scope",
+		Analyzer: "goseal_init",
+		Doc:      "Sealed structs must not be constructed outside the allowed init scope",
+	}
+	RuleFactoryNames = Rule{
+		Code:     "GS002",
+		Option:   "factory-names",
+		Analyzer: "goseal_init",
+		Doc:      "Sealed structs must only be constructed in factory functions",
+	}
+	RuleMutationScope = Rule{
+		Code:     "GS003",
+		Option:   "mutation-scope",
+		Analyzer: "goseal_mutation",
+		Doc:      "Fields of sealed structs must not be assigned outside the allowed mutation scope",
+	}
+	RuleRestrictedFunctions = Rule{
+		Code:     "GS004",
+		Option:   "restricted-functions",
+		Analyzer: "goseal_restricted",
+		Doc:      "Restricted functions must not be called outside their allowed callers",
+	}
+	RuleEncapsulation = Rule{
+		Code:   "GS005",
+		Option: "advise",
+		Doc:    "Exported fields of sealed structs unused outside their package should be unexported",
+	}
+	RuleAliasing = Rule{
+		Code:     "GS006",
+		Option:   "check-aliasing",
+		Analyzer: "goseal_aliasing",
+		Doc:      "Sealed structs must not share slices, maps or pointers with their callers",
+	}
+	RuleCopyScope = Rule{
+		Code:     "GS007",
+		Option:   "copy-scope",
+		Analyzer: "goseal_copy",
+		Doc:      "Sealed structs must not be copied by value outside the allowed copy scope",
+	}
+	RuleRequireAllFields = Rule{
+		Code:     "GS008",
+		Option:   "require-all-fields",
+		Analyzer: "goseal_init",
+		Doc:      "Factory functions must set every field of the sealed structs they construct",
+	}
+	RuleRequireValidation = Rule{
+		Code:     "GS009",
+		Option:   "require-validation",
+		Analyzer: "goseal_init",
+		Doc:      "Factory functions must validate the sealed structs they construct",
+	}
+	RuleInvariantCheck = Rule{
+		Code:     "GS010",
+		Option:   "require-invariant-check",
+		Analyzer: "goseal_mutation",
+		Doc:      "Methods writing fields of sealed structs must re-check their invariants",
+	}
+	RuleExportedVars = Rule{
+		Code:     "GS011",
+		Option:   "report-exported-vars",
+		Analyzer: "goseal_mutation",
+		Doc:      "Exported package-level variables must not hold sealed structs",
+	}
+	RuleFieldTags = Rule{
+		Code:     "GS012",
+		Option:   "goseal-tag",
+		Analyzer: "goseal_mutation",
+		Doc:      "goseal tags on fields of sealed structs must be valid",
+	}
+)
+
+// Rules returns all rules in code order.
+func Rules() []Rule {
+	return []Rule{
+		RuleBaselineFixed,
+		RuleInitScope,
+		RuleFactoryNames,
+		RuleMutationScope,
+		RuleRestrictedFunctions,
+		RuleEncapsulation,
+		RuleAliasing,
+		RuleCopyScope,
+		RuleRequireAllFields,
+		RuleRequireValidation,
+		RuleInvariantCheck,
+		RuleExportedVars,
+		RuleFieldTags,
+	}
+}
+
+// LookupRule returns the rule with the given code.
+func LookupRule(code string) (Rule, bool) {
+	for _, rule := range Rules() {
+		if rule.Code == code {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// URL returns the documentation URL of the rule.
+func (r Rule) URL() string {
+	return rulesDocURL + "#" + strings.ToLower(r.Code)
+}
@@ -0,0 +1,339 @@
+package goseal
+
+import (
+	"encoding/json"
+)
+
+//go:generate go run ./cmd/goseal config schema -o goseal.schema.json
+
+const schemaID = "https://raw.githubusercontent.com/jimmysharp/goseal/master/goseal.schema.json"
+
+// JSONSchema returns the JSON Schema describing .goseal.yml.
+func JSONSchema() ([]byte, error) {
+	schema := map[string]any{
+		"$schema":              "http://json-schema.org/draft-07/schema#",
+		"$id":                  schemaID,
+		"title":                "goseal configuration",
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties":           configSchemaProperties(),
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func configSchemaProperties() map[string]any {
+	return map[string]any{
+		"target-packages": patternListSchema(
+			"Regexps for packages containing target structs. If empty, all packages are targeted.",
+		),
+		"exclude-structs": patternListSchema(
+			"Regexps for struct names to exclude from protection.",
+		),
+		"factory-names": patternListSchema(
+			"Regexps for functions considered as factory functions. If empty, initialization is allowed in any function.",
+		),
+		"init-scope": enumSchema(
+			"Scope for struct initialization.",
+			string(InitScopeSamePackage),
+			string(InitScopeAny),
+			string(InitScopeInTargetPackages),
+			string(InitScopeSamePackage),
+		),
+		"mutation-scope": enumSchema(
+			"Scope for field mutation.",
+			string(MutationScopeReceiver),
+			string(MutationScopeAny),
+			string(MutationScopeInTargetPackages),
+			string(MutationScopeReceiver),
+			string(MutationScopeSamePackage),
+			string(MutationScopeNever),
+		),
+		"copy-scope": enumSchema(
+			"Scope for copying struct values. 'any' disables the check.",
+			string(CopyScopeAny),
+			string(CopyScopeAny),
+			string(CopyScopeInTargetPackages),
+			string(CopyScopeSamePackage),
+		),
+		"closures": enumSchema(
+			"Whether function literals inherit the factory and receiver status of the function declaring them: always (inherit), never (deny), or only for sealed structs of their own package (same-package-only).",
+			string(ClosuresInherit),
+			string(ClosuresInherit),
+			string(ClosuresDeny),
+			string(ClosuresSamePackageOnly),
+		),
+		"ignore-files": map[string]any{
+			"description": "Files to ignore, matched against module-relative, slash-separated paths (e.g. internal/mocks/user.go). Entries are regexps, or objects with a pattern or glob and optionally the rules to ignore.",
+			"type":        "array",
+			"items": map[string]any{
+				"oneOf": []any{
+					map[string]any{
+						"description": "Regexp for the paths of files that are not checked.",
+						"type":        "string",
+						"format":      "regex",
+					},
+					map[string]any{
+						"type":                 "object",
+						"additionalProperties": false,
+						"oneOf": []any{
+							map[string]any{"required": []string{"pattern"}},
+							map[string]any{"required": []string{"glob"}},
+						},
+						"properties": map[string]any{
+							"pattern": map[string]any{
+								"description": "Regexp for the paths.",
+								"type":        "string",
+								"format":      "regex",
+							},
+							"glob": map[string]any{
+								"description": "Glob for the paths, where ** matches any number of directories (e.g. **/mocks/*.go).",
+								"type":        "string",
+							},
+							"rules": map[string]any{
+								"description": "Diagnostic codes of rules that are not reported in the files. If omitted, the files are not checked at all.",
+								"type":        "array",
+								"items": map[string]any{
+									"type": "string",
+									"enum": ruleCodes(),
+								},
+							},
+						},
+					},
+				},
+			},
+			"default": []string{},
+		},
+		"tests": map[string]any{
+			"description":          "Scopes for code in _test.go files and test fixture packages. If omitted, test code is checked like other code.",
+			"type":                 "object",
+			"additionalProperties": false,
+			"properties": map[string]any{
+				"init-scope": enumSchema(
+					"Scope for struct initialization in test code. Defaults to init-scope.",
+					"",
+					string(InitScopeAny),
+					string(InitScopeInTargetPackages),
+					string(InitScopeSamePackage),
+				),
+				"mutation-scope": enumSchema(
+					"Scope for field mutation in test code. Defaults to mutation-scope.",
+					"",
+					string(MutationScopeAny),
+					string(MutationScopeInTargetPackages),
+					string(MutationScopeReceiver),
+					string(MutationScopeSamePackage),
+					string(MutationScopeNever),
+				),
+				"copy-scope": enumSchema(
+					"Scope for copying struct values in test code. Defaults to copy-scope.",
+					"",
+					string(CopyScopeAny),
+					string(CopyScopeInTargetPackages),
+					string(CopyScopeSamePackage),
+				),
+				"external-test-package": boolSchema(
+					"Treat external test packages (e.g. domain_test) as the package under test.",
+					false,
+				),
+				"fixture-packages": patternListSchema(
+					"Regexps for packages treated as test code of every target package (e.g. internal/testutil).",
+				),
+				"enforce-factory-names": boolSchema(
+					"Apply factory-names to test code.",
+					false,
+				),
+			},
+		},
+		"init-allowed-from": patternListSchema(
+			"Regexps for packages allowed to construct any sealed struct, regardless of init-scope and factory-names.",
+		),
+		"mutation-allowed-from": patternListSchema(
+			"Regexps for packages allowed to mutate any sealed struct, regardless of mutation-scope.",
+		),
+		"structs": map[string]any{
+			"description": "Settings for the sealed structs matching a pattern.",
+			"type":        "array",
+			"items": map[string]any{
+				"type":                 "object",
+				"additionalProperties": false,
+				"required":             []string{"pattern"},
+				"properties": map[string]any{
+					"pattern": map[string]any{
+						"description": "Regexp for qualified struct names (e.g. example.com/domain.User).",
+						"type":        "string",
+						"format":      "regex",
+					},
+					"init-allowed-from": patternListSchema(
+						"Regexps for packages allowed to construct the structs.",
+					),
+					"mutation-allowed-from": patternListSchema(
+						"Regexps for packages allowed to mutate the structs.",
+					),
+					"require-all-fields": boolSchema(
+						"Require composite literals of the structs in factory functions to set every field.",
+						false,
+					),
+				},
+			},
+			"default": []any{},
+		},
+		"restricted-functions": map[string]any{
+			"description": "Functions and methods that may only be called from certain packages.",
+			"type":        "array",
+			"items": map[string]any{
+				"type":                 "object",
+				"additionalProperties": false,
+				"required":             []string{"pattern"},
+				"properties": map[string]any{
+					"pattern": map[string]any{
+						"description": "Regexp for qualified function names (e.g. example.com/domain.ReconstituteUser or example.com/domain.User.Restore).",
+						"type":        "string",
+						"format":      "regex",
+					},
+					"scope": enumSchema(
+						"Scope of the allowed callers.",
+						string(InitScopeSamePackage),
+						string(InitScopeAny),
+						string(InitScopeInTargetPackages),
+						string(InitScopeSamePackage),
+					),
+					"allowed-from": patternListSchema(
+						"Regexps for packages allowed to call the functions regardless of scope.",
+					),
+				},
+			},
+			"default": []any{},
+		},
+		"check-aliasing": boolSchema(
+			"Report exported methods returning slice, map or pointer fields of sealed structs, and exported functions storing such arguments in them.",
+			false,
+		),
+		"read-only-methods": patternListSchema(
+			"Regexps for qualified names of pointer-receiver methods that do not write their receiver (e.g. example.com/domain.Tags.Contains), for methods goseal cannot analyze.",
+		),
+		"require-all-fields": boolSchema(
+			"Require composite literals of sealed structs in factory functions to set every field, except fields tagged goseal:\"optional\".",
+			false,
+		),
+		"require-validation": boolSchema(
+			"Require factory functions of sealed structs to return an error, call a method matching validate-methods on the constructed value, or be annotated //goseal:novalidate.",
+			false,
+		),
+		"validate-methods": patternListSchema(
+			"Regexps for names of validation methods (e.g. ^[Vv]alidate$), for require-validation and require-invariant-check, which require at least one.",
+		),
+		"require-invariant-check": boolSchema(
+			"Require pointer-receiver methods of sealed structs that write fields to call a method matching validate-methods on the receiver on every return path after the last write, or to return an error.",
+			false,
+		),
+		"allow-package-vars": boolSchema(
+			"Allow composite literals of sealed structs in package-level variable declarations of the package declaring them, regardless of factory-names.",
+			false,
+		),
+		"allow-init-funcs": boolSchema(
+			"Allow composite literals of sealed structs in init functions of the package declaring them, regardless of factory-names.",
+			false,
+		),
+		"report-exported-vars": boolSchema(
+			"Report exported package-level variables holding a sealed struct or a pointer to one, which any package can reassign.",
+			false,
+		),
+		"generated": map[string]any{
+			"description":          "How files with a \"// Code generated ... DO NOT EDIT.\" header are checked.",
+			"type":                 "object",
+			"additionalProperties": false,
+			"properties": map[string]any{
+				"check": boolSchema(
+					"Check generated files instead of skipping them.",
+					false,
+				),
+				"generators": map[string]any{
+					"description": "Policies for files of specific generators, named in their header (e.g. sqlc for \"Code generated by sqlc. DO NOT EDIT.\"). The first matching policy applies.",
+					"type":        "array",
+					"items": map[string]any{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"pattern"},
+						"properties": map[string]any{
+							"pattern": map[string]any{
+								"description": "Regexp for the generator name.",
+								"type":        "string",
+								"format":      "regex",
+							},
+							"check": map[string]any{
+								"description": "Check the generated files instead of skipping them. Defaults to generated.check.",
+								"type":        "boolean",
+							},
+							"disabled-rules": map[string]any{
+								"description": "Diagnostic codes of rules that are not reported in the generated files.",
+								"type":        "array",
+								"items": map[string]any{
+									"type": "string",
+									"enum": ruleCodes(),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"disabled-rules": map[string]any{
+			"description": "Diagnostic codes of rules that are not reported.",
+			"type":        "array",
+			"items": map[string]any{
+				"type": "string",
+				"enum": ruleCodes(),
+			},
+			"default": []string{},
+		},
+	}
+}
+
+// ruleCodes returns the codes that can be disabled: those of the analyzers.
+func ruleCodes() []string {
+	var codes []string
+	for _, rule := range Rules() {
+		if rule.Analyzer != "" {
+			codes = append(codes, rule.Code)
+		}
+	}
+	return codes
+}
+
+func patternListSchema(description string) map[string]any {
+	return map[string]any{
+		"description": description,
+		"type":        "array",
+		"items": map[string]any{
+			"type":   "string",
+			"format": "regex",
+		},
+		"default": []string{},
+	}
+}
+
+// enumSchema returns a string schema; an empty defaultValue means the default is inherited.
+func enumSchema(description, defaultValue string, values ...string) map[string]any {
+	schema := map[string]any{
+		"description": description,
+		"type":        "string",
+		"enum":        values,
+	}
+	if defaultValue != "" {
+		schema["default"] = defaultValue
+	}
+	return schema
+}
+
+func boolSchema(description string, defaultValue bool) map[string]any {
+	return map[string]any{
+		"description": description,
+		"type":        "boolean",
+		"default":     defaultValue,
+	}
+}
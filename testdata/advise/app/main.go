@@ -0,0 +1,12 @@
+package app
+
+import "example.com/testproject/domain"
+
+func Describe(u *domain.User) (int, string) {
+	u.Name = "Bob"
+	return u.ID, u.GetSecret()
+}
+
+func Origin() domain.Point {
+	return domain.Point{0, 0}
+}
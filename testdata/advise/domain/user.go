@@ -0,0 +1,32 @@
+package domain
+
+type Base struct {
+	Version int
+}
+
+type User struct {
+	Base
+	ID       int
+	Name     string
+	Secret   string
+	Age      int
+	Nickname string `goseal:"-"`
+	Email    string `json:"email"`
+	internal int
+}
+
+type Point struct {
+	X, Y int
+}
+
+func NewUser(id int, name string) *User {
+	return &User{ID: id, Name: name, Secret: "s", internal: 1}
+}
+
+func (u *User) GetSecret() string {
+	return u.Secret
+}
+
+func NewPoint(x, y int) Point {
+	return Point{X: x, Y: y}
+}
@@ -0,0 +1,12 @@
+package domain_test
+
+import (
+	"testing"
+
+	"example.com/testproject/domain"
+)
+
+func TestAge(t *testing.T) {
+	u := domain.NewUser(1, "Alice")
+	_ = u.Age
+}
@@ -0,0 +1,10 @@
+//go:build ignore
+
+package app
+
+import "example.com/testproject/domain"
+
+// SHOULD NOT REPORT: Recorded violation in a file excluded by build constraints
+func Platform() {
+	_ = domain.User{}
+}
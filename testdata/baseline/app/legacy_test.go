@@ -0,0 +1,13 @@
+package app
+
+import (
+	"testing"
+
+	"example.com/testproject/domain"
+)
+
+// SHOULD NOT REPORT: Violation in a test file recorded in the baseline, which
+// the variant of the package without test files leaves to the test variant
+func TestLegacy(t *testing.T) {
+	_ = domain.User{}
+}
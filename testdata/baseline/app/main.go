@@ -0,0 +1,31 @@
+package app // want "baseline entry GS001 for example.com/testproject/domain.User in Removed no longer matches a violation"
+
+import "example.com/testproject/domain"
+
+// SHOULD NOT REPORT: Violations recorded in the baseline
+func Legacy() {
+	_ = domain.User{}
+	user := domain.NewUser(1, "Alice")
+	user.Name = "Bob"
+}
+
+// SHOULD REPORT: Only one construction in this function is recorded in the baseline
+func PartiallyRecorded() {
+	_ = domain.User{}
+	_ = domain.User{} // want "direct construction of sealed struct User is not allowed from outside its package \\(init-scope: same-package\\)"
+}
+
+// SHOULD REPORT: Violation in a function that is not in the baseline
+func Added() {
+	user := domain.NewUser(1, "Alice")
+	user.ID = 2 // want "direct assignment to field ID of sealed struct User is not allowed outside its receiver methods \\(mutation-scope: receiver\\)"
+}
+
+// SHOULD REPORT: The recorded violation has been fixed (baseline)
+func Fixed() { // want "baseline entry GS003 for example.com/testproject/domain.User.Name in Fixed no longer matches a violation"
+	user := domain.NewUser(1, "Alice")
+	user.Rename("Bob")
+}
+
+// SHOULD NOT REPORT: Violation recorded in the baseline for a package-level variable
+var legacyUser = domain.User{ID: 1}
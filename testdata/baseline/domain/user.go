@@ -0,0 +1,19 @@
+package domain
+
+type User struct {
+	ID   int
+	Name string
+}
+
+// SHOULD NOT REPORT: Function matching "^New.*" is considered a factory (factory-names)
+func NewUser(id int, name string) *User {
+	return &User{
+		ID:   id,
+		Name: name,
+	}
+}
+
+// SHOULD NOT REPORT: Assignment in receiver is allowed (mutation-scope: receiver)
+func (u *User) Rename(name string) {
+	u.Name = name
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"maps"
+	"slices"
+)
+
+type Item struct {
+	Name string
+}
+
+// Customer is not sealed, so pointers to it share its fields
+type Customer struct {
+	Name string
+}
+
+// Note is sealed, so pointers to it are safe to share
+type Note struct {
+	Text string
+}
+
+type Order struct {
+	items    []Item
+	tags     map[string]string
+	customer *Customer
+	note     *Note
+	lines    []string `goseal:"-"`
+	total    int
+}
+
+// SHOULD REPORT: Slices, maps and pointers passed by the caller are stored directly
+func NewOrder(items []Item, tags map[string]string, note *Note, total int) *Order {
+	return &Order{
+		items: items, // want "function NewOrder stores caller-supplied slice items in field items of sealed struct Order; store a copy with slices.Clone"
+		tags:  tags,  // want "function NewOrder stores caller-supplied map tags in field tags of sealed struct Order; store a copy with maps.Clone"
+		note:  note,
+		total: total,
+	}
+}
+
+// SHOULD NOT REPORT: Defensive copies
+func NewOrderCopy(items []Item, tags map[string]string, lines ...string) *Order {
+	return &Order{
+		items: slices.Clone(items),
+		tags:  maps.Clone(tags),
+		lines: lines,
+	}
+}
+
+type Batch struct {
+	items []Item
+	size  int
+}
+
+// SHOULD REPORT: Variadic parameters may alias the caller's slice
+func NewBatch(items ...Item) Batch {
+	return Batch{items, len(items)} // want "function NewBatch stores caller-supplied slice items in field items of sealed struct Batch; store a copy with slices.Clone"
+}
+
+// SHOULD REPORT: Setters storing caller-supplied values
+func (o *Order) SetCustomer(c *Customer) {
+	o.customer = c // want "function SetCustomer stores caller-supplied pointer c in field customer of sealed struct Order; store a pointer to a copy of the value"
+}
+
+// SHOULD NOT REPORT: Unexported functions are only called within the package
+func newOrder(items []Item) *Order {
+	return &Order{items: items}
+}
+
+// SHOULD REPORT: Getters returning internal slices, maps and pointers
+func (o *Order) Items() []Item {
+	return o.items // want "method Items returns internal slice field items of sealed struct Order; return a copy with slices.Clone"
+}
+
+func (o *Order) FirstItems(n int) []Item {
+	return o.items[:n] // want "method FirstItems returns internal slice field items of sealed struct Order; return a copy with slices.Clone"
+}
+
+func (o *Order) Tags() map[string]string {
+	return o.tags // want "method Tags returns internal map field tags of sealed struct Order; return a copy with maps.Clone"
+}
+
+func (o *Order) Customer() *Customer {
+	return o.customer // want "method Customer returns internal pointer field customer of sealed struct Order; return a pointer to a copy of the value"
+}
+
+// SHOULD NOT REPORT: Copies, non-reference fields and unsealed fields
+func (o *Order) ItemsCopy() []Item {
+	return slices.Clone(o.items)
+}
+
+func (o *Order) Total() int {
+	return o.total
+}
+
+func (o *Order) Lines() []string {
+	return o.lines
+}
+
+// SHOULD NOT REPORT: Returns from function literals
+func (o *Order) Each(f func(Item)) {
+	get := func() []Item { return o.items }
+	for _, item := range get() {
+		f(item)
+	}
+}
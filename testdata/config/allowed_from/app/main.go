@@ -0,0 +1,13 @@
+package app
+
+import "example.com/testproject/domain"
+
+// SHOULD REPORT: Not a friend package
+func CreateUser() *domain.User {
+	return &domain.User{ID: 1, Name: "Bob"} // want "direct construction of sealed struct User is not allowed from outside its package \\(init-scope: same-package\\)"
+}
+
+// SHOULD REPORT: Not a friend package
+func RenameUser(u *domain.User) {
+	u.Name = "Carol" // want "direct assignment to field Name of sealed struct User is not allowed outside its receiver methods \\(mutation-scope: receiver\\)"
+}
@@ -0,0 +1,20 @@
+package testfixtures
+
+import "example.com/testproject/domain"
+
+// SHOULD NOT REPORT: Allowed to construct any sealed struct (init-allowed-from)
+func User() *domain.User {
+	return &domain.User{ID: 1, Name: "Alice"}
+}
+
+// SHOULD NOT REPORT: Allowed to construct any sealed struct (init-allowed-from)
+func Order() *domain.Order {
+	return &domain.Order{ID: 1, UserID: 1}
+}
+
+// SHOULD REPORT: init-allowed-from does not allow mutation
+func OrderFor(u *domain.User) *domain.Order {
+	o := Order()
+	o.UserID = u.ID // want "direct assignment to field UserID of sealed struct Order is not allowed outside its receiver methods \\(mutation-scope: receiver\\)"
+	return o
+}
@@ -0,0 +1,21 @@
+package domain
+
+type User struct {
+	ID   int
+	Name string
+}
+
+type Order struct {
+	ID     int
+	UserID int
+}
+
+// SHOULD NOT REPORT: Factory function in same package
+func NewUser(id int, name string) *User {
+	return &User{ID: id, Name: name}
+}
+
+// SHOULD NOT REPORT: Factory function in same package
+func NewOrder(id, userID int) *Order {
+	return &Order{ID: id, UserID: userID}
+}
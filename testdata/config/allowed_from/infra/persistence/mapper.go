@@ -0,0 +1,28 @@
+package persistence
+
+import "example.com/testproject/domain"
+
+type userRow struct {
+	ID   int
+	Name string
+}
+
+type orderRow struct {
+	ID     int
+	UserID int
+}
+
+// SHOULD NOT REPORT: Allowed to construct User (structs[0].init-allowed-from)
+func toUser(row userRow) *domain.User {
+	return &domain.User{ID: row.ID, Name: row.Name}
+}
+
+// SHOULD NOT REPORT: Allowed to mutate User (structs[0].mutation-allowed-from)
+func refreshUser(u *domain.User, row userRow) {
+	u.Name = row.Name
+}
+
+// SHOULD REPORT: Only User is trusted to this package
+func toOrder(row orderRow) *domain.Order {
+	return &domain.Order{ID: row.ID, UserID: row.UserID} // want "direct construction of sealed struct Order is not allowed from outside its package \\(init-scope: same-package\\)"
+}
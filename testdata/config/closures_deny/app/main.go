@@ -0,0 +1,20 @@
+package app
+
+import "example.com/testproject/domain"
+
+type Service struct {
+	user *domain.User
+}
+
+// SHOULD REPORT: Function literals are not factory or receiver code, whatever the package of the struct (closures: deny)
+func NewUserFunc() func(name string) *domain.User {
+	return func(name string) *domain.User {
+		return &domain.User{Name: name} // want "direct construction of sealed struct User is not allowed in function literals of factory functions \\(closures: deny\\)"
+	}
+}
+
+func (s *Service) RenameAsync(name string) {
+	go func() {
+		s.user.Name = name // want "direct assignment to field Name of sealed struct User is not allowed in function literals of receiver methods \\(closures: deny\\)"
+	}()
+}
@@ -0,0 +1,69 @@
+package domain
+
+type User struct {
+	ID   int
+	Name string
+}
+
+// SHOULD NOT REPORT: Construction in a factory function
+func NewUser(id int, name string) *User {
+	return &User{ID: id, Name: name}
+}
+
+// SHOULD REPORT: Function literals are not factory code (closures: deny)
+func NewUserFunc(id int) func(name string) *User {
+	return func(name string) *User {
+		return &User{ID: id, Name: name} // want "direct construction of sealed struct User is not allowed in function literals of factory functions \\(closures: deny\\)"
+	}
+}
+
+func NewUserAsync(id int, name string) <-chan *User {
+	ch := make(chan *User, 1)
+	go func() {
+		ch <- &User{ID: id, Name: name} // want "direct construction of sealed struct User is not allowed in function literals of factory functions \\(closures: deny\\)"
+	}()
+	return ch
+}
+
+func NewUserOrDefault(id int, name string) (u *User) {
+	defer func() {
+		if u == nil {
+			u = &User{ID: id, Name: "default"} // want "direct construction of sealed struct User is not allowed in function literals of factory functions \\(closures: deny\\)"
+		}
+	}()
+	if name != "" {
+		u = NewUser(id, name)
+	}
+	return u
+}
+
+// SHOULD REPORT: Closures never make a non-factory function a factory
+func BuildUserFunc() func() *User {
+	return func() *User {
+		return &User{} // want "direct construction of sealed struct User is not allowed outside factory functions \\(factory-names\\)"
+	}
+}
+
+// SHOULD NOT REPORT: Assignment in a receiver method
+func (u *User) SetName(name string) {
+	u.Name = name
+}
+
+// SHOULD REPORT: Function literals are not receiver code (closures: deny)
+func (u *User) Rename(name string) {
+	defer func() {
+		u.Name = name // want "direct assignment to field Name of sealed struct User is not allowed in function literals of receiver methods \\(closures: deny\\)"
+	}()
+}
+
+func (u *User) RenameAsync(name string) {
+	go func() {
+		u.Name = name // want "direct assignment to field Name of sealed struct User is not allowed in function literals of receiver methods \\(closures: deny\\)"
+	}()
+}
+
+func (u *User) Renamer() func(string) {
+	return func(name string) {
+		u.Name = name // want "direct assignment to field Name of sealed struct User is not allowed in function literals of receiver methods \\(closures: deny\\)"
+	}
+}
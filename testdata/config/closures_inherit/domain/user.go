@@ -0,0 +1,27 @@
+package domain
+
+type User struct {
+	ID   int
+	Name string
+}
+
+// SHOULD NOT REPORT: Function literals are factory code of their function (closures: inherit)
+func NewUserFunc(id int) func(name string) *User {
+	return func(name string) *User {
+		return &User{ID: id, Name: name}
+	}
+}
+
+// SHOULD NOT REPORT: Function literals are receiver code of their method (closures: inherit)
+func (u *User) RenameAsync(name string) {
+	go func() {
+		u.Name = name
+	}()
+}
+
+// SHOULD NOT REPORT: Deferred function literals are receiver code of their method (closures: inherit)
+func (u *User) Rename(name string) {
+	defer func() {
+		u.Name = name
+	}()
+}
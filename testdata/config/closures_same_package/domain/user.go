@@ -0,0 +1,19 @@
+package domain
+
+type User struct {
+	ID   int
+	Name string
+}
+
+// SHOULD NOT REPORT: User belongs to the package of the function literal (closures: same-package-only)
+func NewUserFunc(id int) func(name string) *User {
+	return func(name string) *User {
+		return &User{ID: id, Name: name}
+	}
+}
+
+func (u *User) RenameAsync(name string) {
+	go func() {
+		u.Name = name
+	}()
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"fmt"
+
+	"example.com/testproject/domain"
+)
+
+func show(u domain.User) {}
+
+// SHOULD REPORT: Copies of existing values
+func Copies(u *domain.User, users []domain.User, ch chan domain.User) domain.User {
+	u2 := *u                  // want "assignment copies sealed struct User by value, which is not allowed from outside its package \\(copy-scope: same-package\\)"
+	var u3 = u2               // want "variable declaration copies sealed struct User by value, which is not allowed from outside its package \\(copy-scope: same-package\\)"
+	show(u3)                  // want "call of show copies sealed struct User by value, which is not allowed from outside its package \\(copy-scope: same-package\\)"
+	fmt.Println(*u)           // want "call of fmt.Println copies sealed struct User by value, which is not allowed from outside its package \\(copy-scope: same-package\\)"
+	users = append(users, u2) // want "call of append copies sealed struct User by value, which is not allowed from outside its package \\(copy-scope: same-package\\)"
+	_ = []domain.User{u2}     // want "composite literal copies sealed struct User by value, which is not allowed from outside its package \\(copy-scope: same-package\\)"
+	ch <- u2                  // want "send copies sealed struct User by value, which is not allowed from outside its package \\(copy-scope: same-package\\)"
+	for _, v := range users { // want "range var v copies sealed struct User by value, which is not allowed from outside its package \\(copy-scope: same-package\\)"
+		_ = v.ID
+	}
+	return u2 // want "return copies sealed struct User by value, which is not allowed from outside its package \\(copy-scope: same-package\\)"
+}
+
+// SHOULD NOT REPORT: Pointers, new values and index-only ranges
+func NoCopies(u *domain.User, users []domain.User) *domain.User {
+	p := u
+	v := u.Clone()
+	_ = *u
+	for i := range users {
+		_ = users[i].Name
+	}
+	for _, p := range []*domain.User{p} {
+		_ = p
+	}
+	_ = v.ID
+	return p
+}
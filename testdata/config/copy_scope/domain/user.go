@@ -0,0 +1,15 @@
+package domain
+
+type User struct {
+	ID   int
+	Name string
+}
+
+func NewUser(id int, name string) *User {
+	return &User{ID: id, Name: name}
+}
+
+// SHOULD NOT REPORT: Copies within the package (copy-scope: same-package)
+func (u *User) Clone() User {
+	return *u
+}
@@ -0,0 +1,19 @@
+package app
+
+import "example.com/testproject/domain"
+
+// SHOULD REPORT: GS001 (init-scope) is still enabled
+func WithoutFactoryFunction() {
+	_ = domain.User{ // want "direct construction of sealed struct User is not allowed from outside its package \\(init-scope: same-package\\)"
+		ID:   123,
+		Name: "Bob",
+		Age:  25,
+	}
+}
+
+// SHOULD REPORT: GS003 (mutation-scope) is still enabled
+func DirectAssignment() {
+	user, _ := domain.NewUser(123, "Charlie", 35)
+
+	user.Name = "Dave" // want "direct assignment to field Name of sealed struct User is not allowed outside its receiver methods \\(mutation-scope: receiver\\)"
+}
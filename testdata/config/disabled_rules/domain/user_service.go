@@ -0,0 +1,17 @@
+package domain
+
+import "math/rand/v2"
+
+// SHOULD NOT REPORT: GS002 (factory-names) is disabled (disabled-rules)
+func BuildUser(name string, age int) *User {
+	return &User{
+		ID:   rand.Int(),
+		Name: name,
+		Age:  age,
+	}
+}
+
+// SHOULD REPORT: GS003 (mutation-scope) is still enabled
+func UpdateUserWithoutReceiver(u *User, name string) {
+	u.Name = name // want "direct assignment to field Name of sealed struct User is not allowed outside its receiver methods \\(mutation-scope: receiver\\)"
+}
@@ -0,0 +1,28 @@
+package app
+
+import "example.com/testproject/domain"
+
+// SHOULD NOT REPORT: Nickname is not sealed (goseal tag: -)
+func SetNickname(u *domain.User) {
+	u.Nickname = "bob"
+}
+
+// SHOULD REPORT: Email is only assigned within its package
+func SetEmail(u *domain.User) {
+	u.Email = "bob@example.com" // want "direct assignment to field Email of sealed struct User is not allowed from outside its package \\(goseal tag: mutation=same-package\\)"
+}
+
+// SHOULD REPORT: ID is readonly
+func SetID(u *domain.User) {
+	u.ID = 1 // want "direct assignment to field ID of sealed struct User is not allowed anywhere \\(goseal tag: readonly\\)"
+}
+
+// SHOULD NOT REPORT: Value of generic Box is not sealed (goseal tag: -)
+func Fill(b *domain.Box[int]) {
+	b.Value = 1
+}
+
+// SHOULD REPORT: Untagged fields of generic Box fall back to mutation-scope
+func Relabel(b *domain.Box[int]) {
+	b.Label = "x" // want "direct assignment to field Label of sealed struct Box is not allowed outside its receiver methods \\(mutation-scope: receiver\\)"
+}
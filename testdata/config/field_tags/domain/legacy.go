@@ -0,0 +1,6 @@
+package domain
+
+// SHOULD NOT REPORT: Invalid tags in files ignoring GS012 (ignore-files)
+type Legacy struct {
+	Value int `goseal:"mutable"`
+}
@@ -0,0 +1,41 @@
+package domain
+
+type User struct {
+	ID       int    `json:"id" goseal:"readonly"`
+	Name     string `json:"name"`
+	Nickname string `json:"nickname" goseal:"-"`
+	Email    string `goseal:"mutation=same-package"`
+	Score    int    `goseal:"mutable"`            // want "invalid goseal tag on field Score of sealed struct User: unknown option \"mutable\" \\(must be '-', 'readonly', 'mutation=<scope>', or 'optional'\\); the field keeps the mutation scope of the struct"
+	Level    int    `goseal:"mutation=sometimes"` // want "invalid goseal tag on field Level of sealed struct User: invalid mutation: sometimes \\(must be 'any', 'in-target-packages', 'receiver', 'same-package', or 'never'\\); the field keeps the mutation scope of the struct"
+}
+
+type Box[T any] struct {
+	Value T `goseal:"-"`
+	Label string
+}
+
+// SHOULD NOT REPORT: Construction sets readonly fields
+func NewUser(id int, name string) *User {
+	return &User{ID: id, Name: name}
+}
+
+// SHOULD REPORT: readonly fields are not assigned even in receiver methods
+func (u *User) Reset() {
+	u.ID = 0 // want "direct assignment to field ID of sealed struct User is not allowed anywhere \\(goseal tag: readonly\\)"
+	u.Name = ""
+}
+
+// SHOULD NOT REPORT: Email may be assigned within the package (goseal tag: mutation=same-package)
+func ChangeEmail(u *User, email string) {
+	u.Email = email
+}
+
+// SHOULD REPORT: Untagged fields fall back to mutation-scope
+func Rename(u *User, name string) {
+	u.Name = name // want "direct assignment to field Name of sealed struct User is not allowed outside its receiver methods \\(mutation-scope: receiver\\)"
+}
+
+// SHOULD REPORT: Invalid tags fall back to mutation-scope
+func Rescore(u *User, score int) {
+	u.Score = score // want "direct assignment to field Score of sealed struct User is not allowed outside its receiver methods \\(mutation-scope: receiver\\)"
+}
@@ -0,0 +1,10 @@
+package domain
+
+type User struct {
+	ID   int
+	Name string
+}
+
+func NewUser(id int, name string) *User {
+	return &User{ID: id, Name: name}
+}
@@ -0,0 +1,12 @@
+package app
+
+import "example.com/testproject/domain"
+
+// SHOULD NOT REPORT: init-scope is ignored in fixtures_*.go
+func FixtureUser() *domain.User {
+	u := &domain.User{ID: 1, Name: "fixture"}
+
+	// SHOULD REPORT: Other rules are still checked
+	u.Name = "renamed" // want "direct assignment to field Name of sealed struct User is not allowed outside its receiver methods \\(mutation-scope: receiver\\)"
+	return u
+}
@@ -0,0 +1,8 @@
+package mocks
+
+import "example.com/testproject/domain"
+
+// SHOULD NOT REPORT: The file is ignored by a glob
+func MockUser() *domain.User {
+	return &domain.User{ID: 1}
+}
@@ -0,0 +1,10 @@
+package legacy
+
+import "example.com/testproject/domain"
+
+// SHOULD NOT REPORT: The directory is ignored by a regexp
+func CreateUser() *domain.User {
+	u := &domain.User{ID: 1}
+	u.Name = "legacy"
+	return u
+}
@@ -0,0 +1,8 @@
+package app
+
+import "example.com/testproject/domain"
+
+// SHOULD REPORT: The file is not ignored
+func CreateUser() *domain.User {
+	return &domain.User{ID: 1} // want "direct construction of sealed struct User is not allowed from outside its package \\(init-scope: same-package\\)"
+}
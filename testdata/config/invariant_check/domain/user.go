@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+)
+
+type Tags struct {
+	items []string
+}
+
+func (t *Tags) Add(tag string) {
+	t.items = append(t.items, tag)
+}
+
+type User struct {
+	Name string
+	Age  int
+	Tags Tags
+}
+
+func (u *User) validate() {
+	if u.Age < 0 {
+		panic("age must not be negative")
+	}
+}
+
+// SHOULD NOT REPORT: Validated after the write
+func (u *User) SetAge(age int) {
+	u.Age = age
+	u.validate()
+}
+
+// SHOULD REPORT: Returns without validating
+func (u *User) SetName(name string) {
+	u.Name = name
+} // want "method SetName returns after writing fields of sealed struct User without re-checking its invariants \\(require-invariant-check\\); call a method matching validate-methods before returning, or return an error"
+
+// SHOULD REPORT: Only one branch validates
+func (u *User) Birthday(check bool) {
+	u.Age++
+	if check {
+		u.validate()
+		return
+	}
+	return // want "method Birthday returns after writing fields of sealed struct User without re-checking its invariants"
+}
+
+// SHOULD REPORT: Written again after validating
+func (u *User) Rename(name string) {
+	u.validate()
+	u.Name = name
+} // want "method Rename returns after writing fields of sealed struct User without re-checking its invariants"
+
+// SHOULD REPORT: Mutating method calls on fields write the receiver
+func (u *User) Tag(tag string) {
+	u.Tags.Add(tag)
+} // want "method Tag returns after writing fields of sealed struct User without re-checking its invariants"
+
+// SHOULD NOT REPORT: Validated on every path, including loops
+func (u *User) AddTags(tags []string) {
+	for _, tag := range tags {
+		u.Tags.Add(tag)
+	}
+	if len(tags) == 0 {
+		u.validate()
+		return
+	}
+	u.validate()
+}
+
+// SHOULD NOT REPORT: Paths ending in a panic do not return
+func (u *User) MustSetAge(age int) {
+	u.Age = age
+	if age > 200 {
+		panic("age too large")
+	}
+	u.validate()
+}
+
+// SHOULD NOT REPORT: Deferred validation runs on every return path
+func (u *User) Reset() {
+	defer u.validate()
+	*u = User{}
+}
+
+// SHOULD NOT REPORT: Methods returning an error report invalid input themselves
+func (u *User) SetAgeChecked(age int) error {
+	if age < 0 {
+		return errors.New("age must not be negative")
+	}
+	u.Age = age
+	return nil
+}
+
+// SHOULD NOT REPORT: Annotated methods are not checked
+//
+//goseal:novalidate
+func (u *User) ClearName() {
+	u.Name = ""
+}
+
+// SHOULD NOT REPORT: Methods that only read the receiver
+func (u *User) HasTag(tag string) bool {
+	return slices.Contains(u.Tags.items, tag)
+}
+
+// SHOULD NOT REPORT: Writes in function literals do not happen on return
+func (u *User) Setter() func(string) {
+	return func(name string) { u.Name = name }
+}
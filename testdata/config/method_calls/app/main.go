@@ -0,0 +1,21 @@
+package app
+
+import "example.com/testproject/domain"
+
+// SHOULD REPORT: Mutating methods called on fields outside receiver methods
+func Mutate(u *domain.User) {
+	u.Tags.Add("x")        // want "call to mutating method Add on field Tags of sealed struct User is not allowed outside its receiver methods \\(mutation-scope: receiver\\)"
+	u.Tags.Reset()         // want "call to mutating method Reset on field Tags of sealed struct User is not allowed outside its receiver methods \\(mutation-scope: receiver\\)"
+	u.Visits.Inc()         // want "call to mutating method Inc on field Visits of sealed struct User is not allowed outside its receiver methods \\(mutation-scope: receiver\\)"
+	u.Log.WriteString("x") // want "call to mutating method WriteString on field Log of sealed struct User is not allowed outside its receiver methods \\(mutation-scope: receiver\\)"
+}
+
+// SHOULD NOT REPORT: Read-only methods
+func Read(u *domain.User) (bool, int, int, int, int) {
+	return u.Tags.Contains("x"), u.Tags.Len(), u.Visits.Value(), u.Visits.Snapshot(), u.Log.Len()
+}
+
+// SHOULD NOT REPORT: Methods called through pointer fields do not write the field
+func Owner(u *domain.User) {
+	u.Owner.Add("x")
+}
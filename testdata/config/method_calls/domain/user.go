@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+
+	"example.com/testproject/lib"
+)
+
+type Tags struct {
+	values []string
+}
+
+func (t *Tags) Add(v string) {
+	t.values = append(t.values, v)
+}
+
+func (t *Tags) Contains(v string) bool {
+	return slices.Contains(t.values, v)
+}
+
+func (t *Tags) Len() int {
+	return t.count()
+}
+
+func (t *Tags) count() int {
+	return len(t.values)
+}
+
+func (t *Tags) Reset() {
+	t.clear()
+}
+
+func (t *Tags) clear() {
+	t.values = nil
+}
+
+type User struct {
+	Name   string
+	Tags   Tags
+	Visits lib.Counter
+	Log    strings.Builder
+	Owner  *Tags
+}
+
+// SHOULD NOT REPORT: Mutating methods called in a receiver method
+func (u *User) Tag(v string) {
+	u.Tags.Add(v)
+	u.Visits.Inc()
+}
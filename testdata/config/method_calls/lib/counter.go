@@ -0,0 +1,22 @@
+package lib
+
+type Counter struct {
+	n int
+}
+
+func (c *Counter) Inc() {
+	c.n++
+}
+
+func (c *Counter) Value() int {
+	return c.n
+}
+
+// Snapshot passes its receiver on, so it is assumed to write it
+func (c *Counter) Snapshot() int {
+	return observe(c)
+}
+
+func observe(c *Counter) int {
+	return c.n
+}
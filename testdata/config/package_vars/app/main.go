@@ -0,0 +1,18 @@
+package app
+
+import "example.com/testproject/domain"
+
+// SHOULD REPORT: Package-level variables of other packages (allow-package-vars)
+var appPolicy = domain.Policy{Name: "app", Limit: 1} // want "direct construction of sealed struct Policy is not allowed outside factory functions \\(factory-names\\)"
+
+// SHOULD REPORT: Exported package-level variables of other packages (report-exported-vars)
+var Current = domain.NewPolicy("current", 1) // want "exported package-level variable Current holds sealed struct Policy and can be reassigned by any package \\(report-exported-vars\\); unexport it and provide a function returning it"
+
+// SHOULD REPORT: Init functions of other packages (allow-init-funcs)
+func init() {
+	appPolicy = domain.Policy{Name: "init", Limit: 2} // want "direct construction of sealed struct Policy is not allowed outside factory functions \\(factory-names\\)"
+}
+
+func Policy() domain.Policy {
+	return appPolicy
+}
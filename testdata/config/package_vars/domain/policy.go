@@ -0,0 +1,63 @@
+package domain
+
+type Policy struct {
+	Name  string
+	Limit int
+}
+
+// SHOULD NOT REPORT: Construction in a factory function
+func NewPolicy(name string, limit int) *Policy {
+	return &Policy{Name: name, Limit: limit}
+}
+
+// SHOULD NOT REPORT: Package-level variable of the owning package (allow-package-vars); require-all-fields only applies to factories
+var defaultPolicy = Policy{Name: "default"}
+
+var (
+	// SHOULD NOT REPORT: Unexported package-level variables are not reported (report-exported-vars)
+	fallback = &Policy{Name: "fallback", Limit: 1}
+
+	// SHOULD NOT REPORT: Elements of package-level variables (allow-package-vars)
+	presets = []Policy{{Name: "a", Limit: 1}, {Name: "b", Limit: 2}}
+)
+
+// SHOULD REPORT: Exported package-level variables can be reassigned by any package (report-exported-vars)
+var DefaultPolicy = Policy{Name: "default", Limit: 10} // want "exported package-level variable DefaultPolicy holds sealed struct Policy and can be reassigned by any package \\(report-exported-vars\\); unexport it and provide a function returning it"
+
+// SHOULD REPORT: Pointers are reported too (report-exported-vars)
+var StrictPolicy, LaxPolicy = NewPolicy("strict", 1), NewPolicy("lax", 100) // want "exported package-level variable StrictPolicy holds sealed struct Policy" "exported package-level variable LaxPolicy holds sealed struct Policy"
+
+// SHOULD NOT REPORT: Slices of sealed structs are not reported (report-exported-vars)
+var Presets = presets
+
+// SHOULD REPORT: Function literals of package-level variables run later, outside initialization (allow-package-vars)
+var makePolicy = func() Policy {
+	return Policy{Name: "lazy", Limit: 1} // want "direct construction of sealed struct Policy is not allowed outside factory functions \\(factory-names\\)"
+}
+
+// SHOULD NOT REPORT: Immediately invoked function literals run during initialization (allow-package-vars)
+var builtinPolicy = func() *Policy {
+	return &Policy{Name: "builtin", Limit: 5}
+}()
+
+var sentinel *Policy
+
+// SHOULD NOT REPORT: Init function of the owning package (allow-init-funcs)
+func init() {
+	sentinel = &Policy{Name: "sentinel"}
+}
+
+type registry struct{}
+
+// SHOULD REPORT: Methods named init are not init functions (allow-init-funcs)
+func (registry) init() *Policy {
+	return &Policy{Name: "registry", Limit: 1} // want "direct construction of sealed struct Policy is not allowed outside factory functions \\(factory-names\\)"
+}
+
+// Policies returns the package-level policies
+func Policies() []*Policy {
+	var r registry
+	_ = r.init()
+	_ = makePolicy()
+	return []*Policy{&defaultPolicy, fallback, builtinPolicy, sentinel}
+}
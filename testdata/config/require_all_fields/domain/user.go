@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+type User struct {
+	ID        int
+	Name      string
+	CreatedAt time.Time
+	Nickname  string `goseal:"optional"`
+	Deleted   bool   `goseal:"readonly,optional"`
+}
+
+type Point struct {
+	X, Y int
+}
+
+// require-all-fields is not set for Order
+type Order struct {
+	ID    int
+	Total int
+}
+
+// SHOULD NOT REPORT: Every required field is set
+func NewUser(id int, name string) *User {
+	return &User{ID: id, Name: name, CreatedAt: time.Now()}
+}
+
+// SHOULD REPORT: CreatedAt is missing
+func NewUserWithoutTime(id int, name string) *User {
+	return &User{ID: id, Name: name} // want "factory NewUserWithoutTime does not set field CreatedAt of sealed struct User \\(require-all-fields\\); set it or tag it goseal:\"optional\""
+}
+
+// SHOULD REPORT: Empty literals set no fields
+func NewEmptyUser() User {
+	return User{} // want "factory NewEmptyUser does not set fields ID, Name, CreatedAt of sealed struct User \\(require-all-fields\\); set them or tag them goseal:\"optional\""
+}
+
+// SHOULD NOT REPORT: Unkeyed literals set every field
+func NewPoint(x, y int) Point {
+	return Point{x, y}
+}
+
+// SHOULD NOT REPORT: require-all-fields does not apply to Order
+func NewOrder(id int) *Order {
+	return &Order{ID: id}
+}
@@ -0,0 +1,109 @@
+package domain
+
+import "errors"
+
+type User struct {
+	ID   int
+	Name string
+}
+
+func (u *User) validate() error {
+	if u.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+type Point struct {
+	X, Y int
+}
+
+func (p Point) Validate() bool {
+	return p.X >= 0 && p.Y >= 0
+}
+
+// SHOULD NOT REPORT: The factory returns an error
+func NewUser(id int, name string) (*User, error) {
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	return &User{ID: id, Name: name}, nil
+}
+
+// SHOULD NOT REPORT: The factory calls a validation method
+func NewValidatedUser(id int, name string) *User {
+	u := &User{ID: id, Name: name}
+	if err := u.validate(); err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// SHOULD REPORT: The factory neither returns an error nor validates
+func NewUnvalidatedUser(id int, name string) *User { // want "factory NewUnvalidatedUser does not validate sealed struct User \\(require-validation\\); return an error, call a method matching validate-methods, or annotate it //goseal:novalidate"
+	return &User{ID: id, Name: name}
+}
+
+// SHOULD NOT REPORT: The factory is annotated
+//
+//goseal:novalidate
+func NewAnonymousUser() *User {
+	return &User{Name: "anonymous"}
+}
+
+// SHOULD NOT REPORT: Value receivers are validation methods too
+func NewPoint(x, y int) Point {
+	p := Point{X: x, Y: y}
+	if !p.Validate() {
+		panic("invalid point")
+	}
+	return p
+}
+
+// SHOULD REPORT: Validating one struct does not validate the other
+func NewPair(id int) (*User, Point) { // want "factory NewPair does not validate sealed struct Point \\(require-validation\\)"
+	u := &User{ID: id, Name: "pair"}
+	if err := u.validate(); err != nil {
+		panic(err)
+	}
+	return u, Point{}
+}
+
+// SHOULD REPORT: The error of the validation method is discarded
+func NewUncheckedUser(id int, name string) *User { // want "factory NewUncheckedUser does not validate sealed struct User \\(require-validation\\)"
+	u := &User{ID: id, Name: name}
+	_ = u.validate()
+	return u
+}
+
+// SHOULD REPORT: The result of the validation method is not used
+func NewUnusedPoint(x, y int) Point { // want "factory NewUnusedPoint does not validate sealed struct Point \\(require-validation\\)"
+	p := Point{X: x, Y: y}
+	p.Validate()
+	return p
+}
+
+// SHOULD REPORT: Another value of the struct is validated than the constructed one
+func NewCopiedUser(id int, name string, other *User) *User { // want "factory NewCopiedUser does not validate sealed struct User \\(require-validation\\)"
+	u := &User{ID: id, Name: name}
+	if err := other.validate(); err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// SHOULD REPORT: The value is validated before it is constructed again
+func NewReplacedUser(id int, name string) *User { // want "factory NewReplacedUser does not validate sealed struct User \\(require-validation\\)"
+	u := &User{ID: id}
+	if err := u.validate(); err != nil {
+		panic(err)
+	}
+	u = &User{ID: id, Name: name}
+	return u
+}
+
+// SHOULD NOT REPORT: The value is validated in the return statement
+func NewCheckedPoint(x, y int) (Point, bool) {
+	var p = Point{X: x, Y: y}
+	return p, p.Validate()
+}
@@ -0,0 +1,42 @@
+package app
+
+import "example.com/testproject/domain"
+
+// SHOULD NOT REPORT: Unrestricted function
+func CreateUser() (*domain.User, error) {
+	return domain.NewUser(1, "Bob")
+}
+
+// SHOULD REPORT: Call outside the same package (restricted-functions: same-package)
+func LoadUser() *domain.User {
+	return domain.ReconstituteUser(1, "Bob", true) // want "call to restricted function domain.ReconstituteUser is not allowed from outside its package \\(restricted-functions: same-package\\)"
+}
+
+// SHOULD REPORT: Call through a method expression
+func RestoreUser(u *domain.User) {
+	(*domain.User).Restore(u) // want "call to restricted function domain.User.Restore is not allowed from outside target packages \\(restricted-functions: in-target-packages\\)"
+}
+
+// SHOULD REPORT: Function value assigned to a variable
+func LoadUserLater() func(int, string, bool) *domain.User {
+	load := domain.ReconstituteUser // want "use as a value of restricted function domain.ReconstituteUser is not allowed from outside its package \\(restricted-functions: same-package\\)"
+	return load
+}
+
+// SHOULD REPORT: Function value passed as a callback
+func LoadUsers(ids []int) []*domain.User {
+	return mapUsers(ids, domain.ReconstituteUser) // want "use as a value of restricted function domain.ReconstituteUser is not allowed from outside its package \\(restricted-functions: same-package\\)"
+}
+
+// SHOULD REPORT: Method value and method expression
+func RestoreLater(u *domain.User) (func(), func(*domain.User)) {
+	return u.Restore, (*domain.User).Restore // want "use as a value of restricted function domain.User.Restore is not allowed from outside target packages" "use as a value of restricted function domain.User.Restore is not allowed from outside target packages"
+}
+
+func mapUsers(ids []int, load func(int, string, bool) *domain.User) []*domain.User {
+	var users []*domain.User
+	for _, id := range ids {
+		users = append(users, load(id, "", false))
+	}
+	return users
+}
@@ -0,0 +1,13 @@
+package service
+
+import "example.com/testproject/domain"
+
+// SHOULD NOT REPORT: Call in a target package (restricted-functions: in-target-packages)
+func Undelete(u *domain.User) {
+	u.Restore()
+}
+
+// SHOULD REPORT: Call outside the same package (restricted-functions: same-package)
+func Load(id int) *domain.User {
+	return domain.ReconstituteUser(id, "Alice", false) // want "call to restricted function domain.ReconstituteUser is not allowed from outside its package \\(restricted-functions: same-package\\)"
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "errors"
+
+type User struct {
+	ID      int
+	Name    string
+	Deleted bool
+}
+
+func NewUser(id int, name string) (*User, error) {
+	if name == "" {
+		return nil, errors.New("name must not be empty")
+	}
+	return &User{ID: id, Name: name}, nil
+}
+
+// ReconstituteUser rebuilds a stored user without validation.
+func ReconstituteUser(id int, name string, deleted bool) *User {
+	return &User{ID: id, Name: name, Deleted: deleted}
+}
+
+// Restore undoes a deletion.
+func (u *User) Restore() {
+	u.Deleted = false
+}
+
+// SHOULD NOT REPORT: Call in the same package (restricted-functions: same-package)
+func Copy(u *User) *User {
+	return ReconstituteUser(u.ID, u.Name, u.Deleted)
+}
@@ -0,0 +1,29 @@
+package persistence
+
+import "example.com/testproject/domain"
+
+type userRow struct {
+	ID      int
+	Name    string
+	Deleted bool
+}
+
+// SHOULD NOT REPORT: Call from an allowed package (allowed-from)
+func toUser(row userRow) *domain.User {
+	return domain.ReconstituteUser(row.ID, row.Name, row.Deleted)
+}
+
+// SHOULD REPORT: Restore is not allowed from this package
+func restore(u *domain.User) {
+	u.Restore() // want "call to restricted function domain.User.Restore is not allowed from outside target packages \\(restricted-functions: in-target-packages\\)"
+}
+
+// SHOULD NOT REPORT: Function value in an allowed package (allowed-from)
+func toUsers(rows []userRow) []*domain.User {
+	load := domain.ReconstituteUser
+	var users []*domain.User
+	for _, row := range rows {
+		users = append(users, load(row.ID, row.Name, row.Deleted))
+	}
+	return users
+}
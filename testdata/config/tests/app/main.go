@@ -0,0 +1,8 @@
+package app
+
+import "example.com/testproject/domain"
+
+// SHOULD REPORT: Non-test code outside the package is checked with init-scope
+func CreateUser() *domain.User {
+	return &domain.User{ID: 1, Name: "Alice"} // want "direct construction of sealed struct User is not allowed from outside its package \\(init-scope: same-package\\)"
+}
@@ -0,0 +1,13 @@
+package app
+
+import (
+	"testing"
+
+	"example.com/testproject/domain"
+)
+
+// SHOULD REPORT: Tests of other packages are checked with the test scopes
+func TestCreateUser(t *testing.T) {
+	u := &domain.User{ID: 1, Name: "Alice"} // want "direct construction of sealed struct User is not allowed from outside its package \\(tests.init-scope: same-package\\)"
+	u.Name = "Bob"                          // want "direct assignment to field Name of sealed struct User is not allowed from outside its package \\(tests.mutation-scope: same-package\\)"
+}
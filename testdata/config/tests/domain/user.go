@@ -0,0 +1,19 @@
+package domain
+
+type User struct {
+	ID   int
+	Name string
+}
+
+// SHOULD NOT REPORT: Function matching "^New.*" is considered a factory (factory-names)
+func NewUser(id int, name string) *User {
+	return &User{
+		ID:   id,
+		Name: name,
+	}
+}
+
+// SHOULD REPORT: Non-test code is still checked with mutation-scope
+func Rename(u *User, name string) {
+	u.Name = name // want "direct assignment to field Name of sealed struct User is not allowed outside its receiver methods \\(mutation-scope: receiver\\)"
+}
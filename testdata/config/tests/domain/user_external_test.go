@@ -0,0 +1,14 @@
+package domain_test
+
+import (
+	"testing"
+
+	"example.com/testproject/domain"
+)
+
+// SHOULD NOT REPORT: The external test package is treated as the package under test (tests.external-test-package)
+func TestUserExternal(t *testing.T) {
+	u := &domain.User{ID: 1, Name: "Alice"}
+	u.Name = "Bob"
+	_ = u
+}
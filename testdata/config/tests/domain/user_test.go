@@ -0,0 +1,10 @@
+package domain
+
+import "testing"
+
+// SHOULD NOT REPORT: Construction and mutation in an in-package test (tests.init-scope, tests.mutation-scope)
+func TestUser(t *testing.T) {
+	u := &User{ID: 1, Name: "Alice"}
+	u.Name = "Bob"
+	_ = u
+}
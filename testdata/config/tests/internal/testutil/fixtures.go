@@ -0,0 +1,10 @@
+package testutil
+
+import "example.com/testproject/domain"
+
+// SHOULD NOT REPORT: Test fixture packages may construct and mutate sealed structs (tests.fixture-packages)
+func Alice() *domain.User {
+	u := &domain.User{ID: 1, Name: "Alice"}
+	u.Name = "Alice Smith"
+	return u
+}
@@ -0,0 +1,9 @@
+package app
+
+import "example.com/testproject/domain"
+
+// SHOULD REPORT: Quoting "Code generated by x. DO NOT EDIT." outside the
+// header does not make a hand-written file generated
+func QuotedHeader() *domain.User {
+	return &domain.User{ID: 4} // want "direct construction of sealed struct User is not allowed from outside its package \\(init-scope: same-package\\)"
+}
@@ -0,0 +1,22 @@
+package domain
+
+// User has per-struct settings that allow the infra package to construct
+// and mutate it
+type User struct {
+	ID   int
+	Name string
+}
+
+// Order has only the global settings
+type Order struct {
+	ID     int
+	UserID int
+}
+
+func NewUser(id int, name string) *User {
+	return &User{ID: id, Name: name}
+}
+
+func NewOrder(id, userID int) *Order {
+	return &Order{ID: id, UserID: userID}
+}
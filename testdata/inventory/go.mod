@@ -0,0 +1,3 @@
+module example.com/testproject
+
+go 1.26.0
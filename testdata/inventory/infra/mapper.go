@@ -0,0 +1,13 @@
+package infra
+
+import "example.com/testproject/domain"
+
+// Allowed by the settings of User
+func ToUser(id int, name string) *domain.User {
+	return &domain.User{ID: id, Name: name}
+}
+
+// Not allowed: Order has no per-struct settings
+func ToOrder(id, userID int) *domain.Order {
+	return &domain.Order{ID: id, UserID: userID}
+}